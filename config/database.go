@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	"ayoindo/models"
 
@@ -22,8 +23,8 @@ func ConnectDatabase() {
 	dbname := os.Getenv("DB_NAME")
 
 	dsn := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=require TimeZone=Asia/Jakarta",
-		host, port, user, password, dbname,
+		"host=%s port=%s user=%s password=%s dbname=%s sslmode=require TimeZone=%s",
+		host, port, user, password, dbname, models.DefaultTimezone,
 	)
 
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
@@ -39,15 +40,92 @@ func ConnectDatabase() {
 	err = db.AutoMigrate(
 		&models.User{},
 		&models.Team{},
+		&models.Competition{},
+		&models.Season{},
+		&models.SeasonGroup{},
+		&models.TeamDivisionHistory{},
 		&models.Player{},
+		&models.TeamMembership{},
+		&models.TransferWindow{},
+		&models.RosterFreeze{},
+		&models.Venue{},
 		&models.Match{},
+		&models.MatchStatusChange{},
 		&models.MatchResult{},
 		&models.Goal{},
+		&models.ShootoutKick{},
+		&models.MatchEvent{},
+		&models.MatchLineup{},
+		&models.LineupPlayer{},
+		&models.PlayerAppearance{},
+		&models.TeamMatchStats{},
+		&models.PlayerMatchStats{},
+		&models.Official{},
+		&models.MatchOfficial{},
+		&models.Bracket{},
+		&models.BracketSlot{},
 	)
 	if err != nil {
 		log.Fatalf("Failed to auto-migrate: %v", err)
 	}
 
+	backfillMatchKickoffs(db)
+	backfillTeamMemberships(db)
+	backfillForfeitedMatches(db)
+	backfillGroupStageBrackets(db)
+
 	log.Println("Database migrated successfully")
 	DB = db
 }
+
+// backfillMatchKickoffs gives matches saved before kickoff timestamps existed a kickoff from their
+// date and time in the default time zone. Matches with an unreadable date or time are left without one.
+func backfillMatchKickoffs(db *gorm.DB) {
+	var matches []models.Match
+	db.Where("kickoff_at IS NULL").Find(&matches)
+
+	for _, match := range matches {
+		kickoff, err := time.ParseInLocation("2006-01-02 15:04", match.MatchDate+" "+match.MatchTime, Location())
+		if err != nil {
+			log.Printf("Match %d has an invalid date or time %q %q, no kickoff set", match.ID, match.MatchDate, match.MatchTime)
+			continue
+		}
+		db.Model(&match).UpdateColumns(map[string]interface{}{
+			"kickoff_at": kickoff,
+			"timezone":   models.DefaultTimezone,
+		})
+	}
+}
+
+// backfillTeamMemberships starts the membership history of players registered before it existed
+// with their current team, and credits their goals to it
+func backfillTeamMemberships(db *gorm.DB) {
+	var players []models.Player
+	db.Unscoped().
+		Where("NOT EXISTS (SELECT 1 FROM team_memberships WHERE team_memberships.player_id = players.id)").
+		Find(&players)
+
+	for _, player := range players {
+		db.Create(&models.TeamMembership{
+			PlayerID:     player.ID,
+			TeamID:       player.TeamID,
+			FromDate:     player.CreatedAt.In(Location()).Format("2006-01-02"),
+			JerseyNumber: player.JerseyNumber,
+		})
+	}
+
+	db.Exec("UPDATE goals SET team_id = players.team_id FROM players WHERE players.id = goals.player_id AND goals.team_id = 0")
+}
+
+// backfillForfeitedMatches moves walkovers recorded before the forfeited status existed out of completed
+func backfillForfeitedMatches(db *gorm.DB) {
+	db.Exec("UPDATE matches SET status = ? WHERE status = ? AND id IN (SELECT match_id FROM match_results WHERE result_type = ? AND deleted_at IS NULL)",
+		models.MatchStatusForfeited, models.MatchStatusCompleted, models.ResultTypeForfeit)
+}
+
+// backfillGroupStageBrackets flags the brackets seeded from a group stage before the flag existed:
+// those with the seeded bracket's name in a season that has groups
+func backfillGroupStageBrackets(db *gorm.DB) {
+	db.Exec("UPDATE brackets SET group_stage = ? WHERE group_stage = ? AND name = ? AND season_id IN (SELECT season_id FROM season_groups WHERE deleted_at IS NULL)",
+		true, false, models.KnockoutStageBracketName)
+}
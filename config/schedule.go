@@ -0,0 +1,49 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+	_ "time/tzdata" // venues and competitions can use any IANA time zone, whatever the host has installed
+
+	"ayoindo/models"
+)
+
+// defaultMatchDuration covers the playing time, half time and a buffer before the next booking
+const defaultMatchDuration = 120 * time.Minute
+
+// MatchDuration is how long a match occupies its officials, set with MATCH_DURATION_MINUTES
+func MatchDuration() time.Duration {
+	if minutes, err := strconv.Atoi(os.Getenv("MATCH_DURATION_MINUTES")); err == nil && minutes > 0 {
+		return time.Duration(minutes) * time.Minute
+	}
+	return defaultMatchDuration
+}
+
+// Location is the league's default time zone, the same as the database connection's
+func Location() *time.Location {
+	loc, err := time.LoadLocation(models.DefaultTimezone)
+	if err != nil {
+		return time.FixedZone("WIB", 7*60*60)
+	}
+	return loc
+}
+
+// LoadLocation returns the named IANA time zone, or the league's default for an empty name
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return Location(), nil
+	}
+	return time.LoadLocation(name)
+}
+
+// defaultMinRest is the shortest time between the kickoffs of two matches of a team
+const defaultMinRest = 24 * time.Hour
+
+// MinRestPeriod is the shortest time a team must have between two kickoffs, set with MIN_REST_HOURS
+func MinRestPeriod() time.Duration {
+	if hours, err := strconv.Atoi(os.Getenv("MIN_REST_HOURS")); err == nil && hours >= 0 {
+		return time.Duration(hours) * time.Hour
+	}
+	return defaultMinRest
+}
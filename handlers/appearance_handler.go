@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"fmt"
+
+	"ayoindo/config"
+	"ayoindo/models"
+
+	"gorm.io/gorm"
+)
+
+type AppearanceTotals struct {
+	Appearances int `json:"appearances"`
+	Starts      int `json:"starts"`
+	Minutes     int `json:"minutes"`
+}
+
+type SeasonAppearances struct {
+	SeasonID   uint   `json:"season_id"`
+	SeasonName string `json:"season_name"`
+	AppearanceTotals
+}
+
+type PlayerStats struct {
+	Career  AppearanceTotals    `json:"career"`
+	Seasons []SeasonAppearances `json:"seasons"`
+}
+
+// PlayerDetail is a player with their appearance record
+type PlayerDetail struct {
+	models.Player
+	Stats PlayerStats `json:"stats"`
+}
+
+// matchLength returns the regulation length of a match: the length configured on its competition,
+// or the default for matches outside a season
+func matchLength(db *gorm.DB, match models.Match) int {
+	if match.SeasonID == nil {
+		return models.DefaultMatchLength
+	}
+	var season models.Season
+	if err := db.Preload("Competition").First(&season, *match.SeasonID).Error; err != nil ||
+		season.Competition == nil || season.Competition.MatchLength == 0 {
+		return models.DefaultMatchLength
+	}
+	return season.Competition.MatchLength
+}
+
+// buildAppearances works out who was on the pitch and for how long from the lineups and the
+// substitutions and sendings-off of the timeline, which must be in chronological order.
+// fullTime is the last minute of the match. Teams without a lineup get no appearances.
+func buildAppearances(match models.Match, lineups []models.MatchLineup, events []models.MatchEvent, fullTime int) ([]models.PlayerAppearance, error) {
+	var appearances []models.PlayerAppearance
+
+	for _, lineup := range lineups {
+		onPitch := make(map[uint]*models.PlayerAppearance)
+		bench := make(map[uint]bool)
+		var order []*models.PlayerAppearance
+
+		for _, p := range lineup.Players {
+			if !p.Starter {
+				bench[p.PlayerID] = true
+				continue
+			}
+			a := &models.PlayerAppearance{MatchID: match.ID, PlayerID: p.PlayerID, TeamID: lineup.TeamID, Starter: true}
+			onPitch[p.PlayerID] = a
+			order = append(order, a)
+		}
+
+		for _, e := range events {
+			if e.TeamID == nil || *e.TeamID != lineup.TeamID || e.PlayerID == nil {
+				continue
+			}
+			minute := e.Minute
+			if minute > fullTime {
+				minute = fullTime
+			}
+
+			switch e.Type {
+			case models.EventSubstitution:
+				off, ok := onPitch[*e.RelatedPlayerID]
+				if !ok {
+					return nil, fmt.Errorf("player %d is not on the pitch to be substituted at minute %d", *e.RelatedPlayerID, e.Minute)
+				}
+				if !bench[*e.PlayerID] {
+					return nil, fmt.Errorf("player %d is not an unused substitute at minute %d", *e.PlayerID, e.Minute)
+				}
+				off.MinuteOff = minute
+				delete(onPitch, *e.RelatedPlayerID)
+				delete(bench, *e.PlayerID)
+
+				on := &models.PlayerAppearance{MatchID: match.ID, PlayerID: *e.PlayerID, TeamID: lineup.TeamID, MinuteOn: minute}
+				onPitch[*e.PlayerID] = on
+				order = append(order, on)
+			case models.EventRedCard, models.EventSecondYellow:
+				// A substitute sent off from the bench never played
+				if sentOff, ok := onPitch[*e.PlayerID]; ok {
+					sentOff.MinuteOff = minute
+					delete(onPitch, *e.PlayerID)
+				}
+			}
+		}
+
+		for _, a := range onPitch {
+			a.MinuteOff = fullTime
+		}
+		for _, a := range order {
+			a.MinutesPlayed = a.MinuteOff - a.MinuteOn
+			appearances = append(appearances, *a)
+		}
+	}
+	return appearances, nil
+}
+
+// playerStats totals the appearances of a player in completed matches, overall and per season
+func playerStats(playerID uint) PlayerStats {
+	appearances := func() *gorm.DB {
+		return completedMatchesQuery(config.DB).
+			Joins("JOIN player_appearances ON player_appearances.match_result_id = match_results.id AND player_appearances.deleted_at IS NULL").
+			Where("player_appearances.player_id = ?", playerID)
+	}
+	const totals = "COUNT(*) AS appearances, " +
+		"COALESCE(SUM(CASE WHEN player_appearances.starter THEN 1 ELSE 0 END), 0) AS starts, " +
+		"COALESCE(SUM(player_appearances.minutes_played), 0) AS minutes"
+
+	stats := PlayerStats{Seasons: []SeasonAppearances{}}
+	appearances().Select(totals).Scan(&stats.Career)
+	appearances().
+		Joins("JOIN seasons ON seasons.id = matches.season_id").
+		Select("seasons.id AS season_id, seasons.name AS season_name, " + totals).
+		Group("seasons.id, seasons.name, seasons.start_date").
+		Order("seasons.start_date ASC").
+		Scan(&stats.Seasons)
+	return stats
+}
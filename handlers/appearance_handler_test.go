@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"testing"
+
+	"ayoindo/models"
+)
+
+func uintPtr(v uint) *uint { return &v }
+
+func lineup(teamID uint, starters, bench []uint) models.MatchLineup {
+	l := models.MatchLineup{TeamID: teamID}
+	for _, id := range starters {
+		l.Players = append(l.Players, models.LineupPlayer{PlayerID: id, Starter: true})
+	}
+	for _, id := range bench {
+		l.Players = append(l.Players, models.LineupPlayer{PlayerID: id})
+	}
+	return l
+}
+
+func substitution(teamID, on, off uint, minute int) models.MatchEvent {
+	return models.MatchEvent{Type: models.EventSubstitution, Minute: minute, TeamID: uintPtr(teamID), PlayerID: uintPtr(on), RelatedPlayerID: uintPtr(off)}
+}
+
+func sendingOff(teamID, playerID uint, minute int) models.MatchEvent {
+	return models.MatchEvent{Type: models.EventRedCard, Minute: minute, TeamID: uintPtr(teamID), PlayerID: uintPtr(playerID)}
+}
+
+func TestBuildAppearances(t *testing.T) {
+	match := models.Match{ID: 1, HomeTeamID: 1, AwayTeamID: 2}
+	lineups := []models.MatchLineup{
+		lineup(1, []uint{11, 12, 13}, []uint{14, 15}),
+		lineup(2, []uint{21}, []uint{22}),
+	}
+	events := []models.MatchEvent{
+		substitution(1, 14, 11, 60),
+		sendingOff(1, 12, 75),
+		{Type: models.EventSecondYellow, Minute: 80, TeamID: uintPtr(2), PlayerID: uintPtr(22)}, // sent off from the bench
+		substitution(1, 15, 14, 93), // stoppage time counts as full time
+	}
+
+	appearances, err := buildAppearances(match, lineups, events, 90)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []struct {
+		playerID, teamID uint
+		starter          bool
+		on, off, minutes int
+	}{
+		{playerID: 11, teamID: 1, starter: true, on: 0, off: 60, minutes: 60},
+		{playerID: 12, teamID: 1, starter: true, on: 0, off: 75, minutes: 75},
+		{playerID: 13, teamID: 1, starter: true, on: 0, off: 90, minutes: 90},
+		{playerID: 14, teamID: 1, on: 60, off: 90, minutes: 30},
+		{playerID: 15, teamID: 1, on: 90, off: 90, minutes: 0},
+		{playerID: 21, teamID: 2, starter: true, on: 0, off: 90, minutes: 90},
+	}
+	if len(appearances) != len(want) {
+		t.Fatalf("got %d appearances, want %d: %+v", len(appearances), len(want), appearances)
+	}
+	for i, w := range want {
+		a := appearances[i]
+		if a.MatchID != match.ID || a.PlayerID != w.playerID || a.TeamID != w.teamID || a.Starter != w.starter {
+			t.Errorf("appearance %d: got player %d of team %d (starter %v), want player %d of team %d (starter %v)",
+				i, a.PlayerID, a.TeamID, a.Starter, w.playerID, w.teamID, w.starter)
+		}
+		if a.MinuteOn != w.on || a.MinuteOff != w.off || a.MinutesPlayed != w.minutes {
+			t.Errorf("player %d: got minutes %d-%d (%d played), want %d-%d (%d played)",
+				a.PlayerID, a.MinuteOn, a.MinuteOff, a.MinutesPlayed, w.on, w.off, w.minutes)
+		}
+	}
+}
+
+func TestBuildAppearancesInvalidSubstitutions(t *testing.T) {
+	match := models.Match{ID: 1, HomeTeamID: 1, AwayTeamID: 2}
+	lineups := []models.MatchLineup{lineup(1, []uint{11, 12}, []uint{14, 15})}
+
+	tests := []struct {
+		name   string
+		events []models.MatchEvent
+	}{
+		{name: "player off is on the bench", events: []models.MatchEvent{substitution(1, 14, 15, 60)}},
+		{name: "player off was already substituted", events: []models.MatchEvent{substitution(1, 14, 11, 60), substitution(1, 15, 11, 70)}},
+		{name: "player off was sent off", events: []models.MatchEvent{sendingOff(1, 11, 30), substitution(1, 14, 11, 60)}},
+		{name: "player on is a starter", events: []models.MatchEvent{substitution(1, 12, 11, 60)}},
+		{name: "player on has already played", events: []models.MatchEvent{substitution(1, 14, 11, 60), substitution(1, 14, 12, 70)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := buildAppearances(match, lineups, tt.events, 90); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
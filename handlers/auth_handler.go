@@ -59,11 +59,19 @@ func Register(c *gin.Context) {
 		return
 	}
 
+	// The first account administers the installation; later accounts are regular users
+	role := models.RoleUser
+	var users int64
+	config.DB.Unscoped().Model(&models.User{}).Count(&users)
+	if users == 0 {
+		role = models.RoleAdmin
+	}
+
 	user := models.User{
 		Username: input.Username,
 		Email:    input.Email,
 		Password: string(hashed),
-		Role:     "admin",
+		Role:     role,
 	}
 
 	if err := config.DB.Create(&user).Error; err != nil {
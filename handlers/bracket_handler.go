@@ -0,0 +1,391 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"ayoindo/config"
+	"ayoindo/models"
+	"ayoindo/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type BracketInput struct {
+	Name         string `json:"name" binding:"required,min=2,max=100"`
+	TeamIDs      []uint `json:"team_ids" binding:"required,min=2"`                 // seeding order, best seed first
+	StartDate    string `json:"start_date" binding:"required,datetime=2006-01-02"` // YYYY-MM-DD, date of round 1
+	IntervalDays int    `json:"interval_days" binding:"required,min=1,max=60"`     // days between rounds
+	MatchTime    string `json:"match_time" binding:"required,datetime=15:04"`      // HH:MM
+}
+
+type BracketRound struct {
+	Round int                  `json:"round"`
+	Name  string               `json:"name"`
+	Slots []models.BracketSlot `json:"slots"`
+}
+
+type BracketTree struct {
+	Bracket  models.Bracket `json:"bracket"`
+	Rounds   []BracketRound `json:"rounds"`
+	Champion *models.Team   `json:"champion"`
+}
+
+var errNextRoundPlayed = errors.New("the next round match has already been played")
+
+// officialConflictError is returned when a new team of a next round match cannot be officiated by
+// an official already assigned to that match
+type officialConflictError struct {
+	MatchID  uint
+	Conflict string
+}
+
+func (e *officialConflictError) Error() string {
+	return fmt.Sprintf("match %d: %s", e.MatchID, e.Conflict)
+}
+
+// bracketSeedOrder returns the round 1 seed layout for a bracket of the given size,
+// e.g. 1,8,4,5,2,7,3,6 for eight positions, so the top two seeds can only meet in the final.
+func bracketSeedOrder(size int) []int {
+	order := []int{1}
+	for len(order) < size {
+		n := len(order) * 2
+		next := make([]int, 0, n)
+		for _, s := range order {
+			next = append(next, s, n+1-s)
+		}
+		order = next
+	}
+	return order
+}
+
+func bracketRoundName(round, rounds int) string {
+	switch rounds - round {
+	case 0:
+		return "Final"
+	case 1:
+		return "Semi-final"
+	case 2:
+		return "Quarter-final"
+	}
+	return fmt.Sprintf("Round of %d", 1<<(rounds-round+1))
+}
+
+// ensureSlotMatch creates the slot's match once both teams are known,
+// or moves an existing unplayed match to the slot's current teams.
+func ensureSlotMatch(tx *gorm.DB, bracket *models.Bracket, slot *models.BracketSlot) error {
+	if slot.IsBye || slot.HomeTeamID == nil || slot.AwayTeamID == nil {
+		return nil
+	}
+
+	if slot.MatchID != nil {
+		var match models.Match
+		if err := tx.First(&match, *slot.MatchID).Error; err != nil {
+			return err
+		}
+		if match.HomeTeamID == *slot.HomeTeamID && match.AwayTeamID == *slot.AwayTeamID {
+			return nil
+		}
+		if match.Status.IsDecided() {
+			return errNextRoundPlayed
+		}
+		match.HomeTeamID = *slot.HomeTeamID
+		match.AwayTeamID = *slot.AwayTeamID
+		if conflict := matchOfficialsConflict(tx, match); conflict != "" {
+			return &officialConflictError{MatchID: match.ID, Conflict: conflict}
+		}
+		match.Sequence++
+		if err := tx.Save(&match).Error; err != nil {
+			return err
+		}
+		return removeStaleLineups(tx, match)
+	}
+
+	start, _ := time.Parse("2006-01-02", bracket.StartDate)
+	seasonID := bracket.SeasonID
+	match := models.Match{
+		HomeTeamID: *slot.HomeTeamID,
+		AwayTeamID: *slot.AwayTeamID,
+		SeasonID:   &seasonID,
+		Matchday:   slot.Round,
+		Status:     models.MatchStatusScheduled,
+	}
+	loc := kickoffLocation(tx, nil, &seasonID)
+	kickoff, err := parseLocalKickoff(start.AddDate(0, 0, (slot.Round-1)*bracket.IntervalDays).Format("2006-01-02"), bracket.MatchTime, loc)
+	if err != nil {
+		return err
+	}
+	setKickoff(&match, kickoff, loc)
+	if err := tx.Create(&match).Error; err != nil {
+		return err
+	}
+	slot.MatchID = &match.ID
+	return tx.Save(slot).Error
+}
+
+// advanceBracketWinner records the winner of a slot and places it in the next round
+func advanceBracketWinner(tx *gorm.DB, bracket *models.Bracket, slot *models.BracketSlot, winnerID uint) error {
+	slot.WinnerTeamID = &winnerID
+	if err := tx.Save(slot).Error; err != nil {
+		return err
+	}
+	if slot.Round == bracket.Rounds {
+		return nil
+	}
+
+	var next models.BracketSlot
+	if err := tx.Where("bracket_id = ? AND round = ? AND position = ?",
+		bracket.ID, slot.Round+1, slot.Position/2).First(&next).Error; err != nil {
+		return err
+	}
+
+	if slot.Position%2 == 0 {
+		next.HomeTeamID = &winnerID
+	} else {
+		next.AwayTeamID = &winnerID
+	}
+	if err := tx.Save(&next).Error; err != nil {
+		return err
+	}
+	return ensureSlotMatch(tx, bracket, &next)
+}
+
+// advanceBracketForMatch moves the winner of a completed knockout match on to the next round.
+// Matches that are not part of a bracket are ignored.
+func advanceBracketForMatch(tx *gorm.DB, match models.Match, winnerID uint) error {
+	var slot models.BracketSlot
+	if err := tx.Where("match_id = ?", match.ID).First(&slot).Error; err != nil {
+		return nil
+	}
+
+	var bracket models.Bracket
+	if err := tx.First(&bracket, slot.BracketID).Error; err != nil {
+		return err
+	}
+	return advanceBracketWinner(tx, &bracket, &slot, winnerID)
+}
+
+// bracketMatches returns the matches scheduled so far in a bracket
+func bracketMatches(db *gorm.DB, bracketID uint) []models.Match {
+	var matches []models.Match
+	db.Where("id IN (?)", db.Model(&models.BracketSlot{}).Select("match_id").Where("bracket_id = ?", bracketID)).
+		Find(&matches)
+	return matches
+}
+
+// nextRoundMatch returns the match the winner of a knockout match plays next, once it is scheduled
+func nextRoundMatch(db *gorm.DB, matchID uint) (models.Match, bool) {
+	var slot models.BracketSlot
+	if err := db.Where("match_id = ?", matchID).First(&slot).Error; err != nil {
+		return models.Match{}, false
+	}
+	var next models.BracketSlot
+	if err := db.Where("bracket_id = ? AND round = ? AND position = ?", slot.BracketID, slot.Round+1, slot.Position/2).
+		First(&next).Error; err != nil || next.MatchID == nil {
+		return models.Match{}, false
+	}
+	var match models.Match
+	if err := db.First(&match, *next.MatchID).Error; err != nil {
+		return models.Match{}, false
+	}
+	return match, true
+}
+
+// isKnockoutMatch reports whether the match belongs to a bracket slot
+func isKnockoutMatch(matchID uint) bool {
+	var count int64
+	config.DB.Model(&models.BracketSlot{}).Where("match_id = ?", matchID).Count(&count)
+	return count > 0
+}
+
+// createBracket lays out every slot of the bracket, seeds round 1, resolves byes
+// and schedules the round 1 matches. The bracket's schedule fields must be set.
+func createBracket(tx *gorm.DB, bracket *models.Bracket, seededTeamIDs []uint) error {
+	size := 2
+	rounds := 1
+	for size < len(seededTeamIDs) {
+		size *= 2
+		rounds++
+	}
+	bracket.Size = size
+	bracket.Rounds = rounds
+
+	if err := tx.Create(bracket).Error; err != nil {
+		return err
+	}
+
+	var slots []models.BracketSlot
+	for round := 1; round <= rounds; round++ {
+		for pos := 0; pos < size>>round; pos++ {
+			slots = append(slots, models.BracketSlot{
+				BracketID: bracket.ID,
+				Round:     round,
+				Position:  pos,
+			})
+		}
+	}
+
+	// Seeds beyond the number of teams are byes; the seed order pairs them with the top seeds
+	order := bracketSeedOrder(size)
+	teamForSeed := func(seed int) *uint {
+		if seed > len(seededTeamIDs) {
+			return nil
+		}
+		id := seededTeamIDs[seed-1]
+		return &id
+	}
+	for pos := 0; pos < size/2; pos++ {
+		slot := &slots[pos]
+		slot.HomeSeed = order[2*pos]
+		slot.AwaySeed = order[2*pos+1]
+		slot.HomeTeamID = teamForSeed(slot.HomeSeed)
+		slot.AwayTeamID = teamForSeed(slot.AwaySeed)
+		slot.IsBye = slot.HomeTeamID == nil || slot.AwayTeamID == nil
+	}
+
+	if err := tx.Create(&slots).Error; err != nil {
+		return err
+	}
+
+	for pos := 0; pos < size/2; pos++ {
+		slot := &slots[pos]
+		if !slot.IsBye {
+			if err := ensureSlotMatch(tx, bracket, slot); err != nil {
+				return err
+			}
+			continue
+		}
+		winner := slot.HomeTeamID
+		if winner == nil {
+			winner = slot.AwayTeamID
+		}
+		if err := advanceBracketWinner(tx, bracket, slot, *winner); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetSeasonBrackets godoc
+// GET /api/seasons/:id/brackets
+func GetSeasonBrackets(c *gin.Context) {
+	id := c.Param("id")
+	var season models.Season
+	if err := config.DB.First(&season, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Season not found")
+		return
+	}
+
+	var brackets []models.Bracket
+	config.DB.Where("season_id = ?", season.ID).Find(&brackets)
+
+	utils.SuccessResponse(c, http.StatusOK, "Brackets retrieved successfully", brackets)
+}
+
+// CreateSeasonBracket godoc
+// POST /api/seasons/:id/brackets
+func CreateSeasonBracket(c *gin.Context) {
+	id := c.Param("id")
+	var season models.Season
+	if err := config.DB.First(&season, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Season not found")
+		return
+	}
+
+	var input BracketInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	seen := make(map[uint]bool)
+	for _, teamID := range input.TeamIDs {
+		if seen[teamID] {
+			utils.ValidationErrorResponse(c, "A team cannot be seeded twice")
+			return
+		}
+		seen[teamID] = true
+		if !seasonHasTeam(season.ID, teamID) {
+			utils.ValidationErrorResponse(c, "All teams must be registered in the season")
+			return
+		}
+	}
+
+	bracket := models.Bracket{
+		SeasonID:     season.ID,
+		Name:         input.Name,
+		StartDate:    input.StartDate,
+		IntervalDays: input.IntervalDays,
+		MatchTime:    input.MatchTime,
+	}
+
+	tx := config.DB.Begin()
+	if err := createBracket(tx, &bracket, input.TeamIDs); err != nil {
+		tx.Rollback()
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create bracket")
+		return
+	}
+
+	if !rejectScheduleConflicts(c, tx, bracketMatches(tx, bracket.ID)) {
+		tx.Rollback()
+		return
+	}
+	tx.Commit()
+
+	tree, _ := loadBracketTree(bracket.ID)
+	utils.SuccessResponse(c, http.StatusCreated, "Bracket created successfully", tree)
+}
+
+func loadBracketTree(id interface{}) (BracketTree, error) {
+	var bracket models.Bracket
+	if err := config.DB.First(&bracket, id).Error; err != nil {
+		return BracketTree{}, err
+	}
+
+	var slots []models.BracketSlot
+	config.DB.
+		Preload("HomeTeam").
+		Preload("AwayTeam").
+		Preload("Match").
+		Preload("Match.MatchResult").
+		Where("bracket_id = ?", bracket.ID).
+		Order("round ASC, position ASC").
+		Find(&slots)
+
+	tree := BracketTree{Bracket: bracket}
+	for round := 1; round <= bracket.Rounds; round++ {
+		tree.Rounds = append(tree.Rounds, BracketRound{
+			Round: round,
+			Name:  bracketRoundName(round, bracket.Rounds),
+			Slots: []models.BracketSlot{},
+		})
+	}
+	for _, slot := range slots {
+		if slot.Round < 1 || slot.Round > bracket.Rounds {
+			continue
+		}
+		tree.Rounds[slot.Round-1].Slots = append(tree.Rounds[slot.Round-1].Slots, slot)
+		if slot.Round == bracket.Rounds && slot.WinnerTeamID != nil {
+			var champion models.Team
+			if err := config.DB.First(&champion, *slot.WinnerTeamID).Error; err == nil {
+				tree.Champion = &champion
+			}
+		}
+	}
+	return tree, nil
+}
+
+// GetBracketByID godoc
+// GET /api/brackets/:id — the whole tree, round by round
+func GetBracketByID(c *gin.Context) {
+	tree, err := loadBracketTree(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Bracket not found")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Bracket retrieved successfully", tree)
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import "testing"
+
+func TestBracketSeedOrder(t *testing.T) {
+	tests := []struct {
+		size int
+		want []int
+	}{
+		{size: 2, want: []int{1, 2}},
+		{size: 4, want: []int{1, 4, 2, 3}},
+		{size: 8, want: []int{1, 8, 4, 5, 2, 7, 3, 6}},
+		{size: 16, want: []int{1, 16, 8, 9, 4, 13, 5, 12, 2, 15, 7, 10, 3, 14, 6, 11}},
+	}
+
+	for _, tt := range tests {
+		order := bracketSeedOrder(tt.size)
+		if len(order) != len(tt.want) {
+			t.Fatalf("size %d: got %d slots, want %d", tt.size, len(order), len(tt.want))
+		}
+		for i := range tt.want {
+			if order[i] != tt.want[i] {
+				t.Fatalf("size %d: got %v, want %v", tt.size, order, tt.want)
+			}
+		}
+		// Every first-round pairing adds up to size+1, so seed 1 meets the lowest seed
+		for i := 0; i < len(order); i += 2 {
+			if order[i]+order[i+1] != tt.size+1 {
+				t.Errorf("size %d: seeds %d and %d meet in the first round", tt.size, order[i], order[i+1])
+			}
+		}
+	}
+}
+
+func TestBracketSeedOrderKeepsTopSeedsApart(t *testing.T) {
+	order := bracketSeedOrder(16)
+	half := len(order) / 2
+	for i, s := range order {
+		if s == 2 && i < half {
+			t.Errorf("seeds 1 and 2 are in the same half: %v", order)
+		}
+	}
+}
+
+func TestBracketRoundName(t *testing.T) {
+	tests := []struct {
+		round, rounds int
+		want          string
+	}{
+		{round: 1, rounds: 1, want: "Final"},
+		{round: 1, rounds: 2, want: "Semi-final"},
+		{round: 1, rounds: 3, want: "Quarter-final"},
+		{round: 1, rounds: 4, want: "Round of 16"},
+		{round: 1, rounds: 5, want: "Round of 32"},
+		{round: 4, rounds: 5, want: "Semi-final"},
+	}
+
+	for _, tt := range tests {
+		if got := bracketRoundName(tt.round, tt.rounds); got != tt.want {
+			t.Errorf("bracketRoundName(%d, %d) = %q, want %q", tt.round, tt.rounds, got, tt.want)
+		}
+	}
+}
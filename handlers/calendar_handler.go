@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"ayoindo/config"
+	"ayoindo/models"
+	"ayoindo/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// icsLineLimit is the longest content line in octets before it must be folded (RFC 5545 3.1)
+const icsLineLimit = 75
+
+// calendarMatchesQuery loads what a calendar event shows: teams, venue, competition and result
+func calendarMatchesQuery(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("HomeTeam").
+		Preload("AwayTeam").
+		Preload("Venue").
+		Preload("Season").
+		Preload("Season.Competition").
+		Preload("MatchResult").
+		Where("matches.kickoff_at IS NOT NULL").
+		Order("matches.kickoff_at ASC, matches.id ASC")
+}
+
+// calendarEventStatus is the STATUS of a match's event. Cancelled matches stay in the feed so
+// subscribed calendars mark them cancelled, and matches without a firm kickoff are tentative.
+func calendarEventStatus(status models.MatchStatus) string {
+	switch status {
+	case models.MatchStatusCancelled:
+		return "CANCELLED"
+	case models.MatchStatusPostponed, models.MatchStatusAbandoned:
+		return "TENTATIVE"
+	}
+	return "CONFIRMED"
+}
+
+// bumpCalendarSequence raises the sequence of the matches picked by the query after a change to
+// what their events show, so subscribed calendars replace their copy
+func bumpCalendarSequence(db *gorm.DB, query interface{}, args ...interface{}) error {
+	return db.Model(&models.Match{}).Where(query, args...).Update("sequence", gorm.Expr("sequence + 1")).Error
+}
+
+// icsText escapes a TEXT value
+func icsText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// icsTime formats an instant as a UTC DATE-TIME
+func icsTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// icsFold splits a content line into lines of at most icsLineLimit octets without breaking a character
+func icsFold(line string) string {
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		n := utf8.RuneLen(r)
+		if width+n > icsLineLimit {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += n
+	}
+	return b.String()
+}
+
+// calendarScore is the final score of a result, with the shootout when there was one
+func calendarScore(r *models.MatchResult) string {
+	score := fmt.Sprintf("%d-%d", r.HomeScore, r.AwayScore)
+	if r.HomePenaltyScore != nil && r.AwayPenaltyScore != nil {
+		score += fmt.Sprintf(" (pen. %d-%d)", *r.HomePenaltyScore, *r.AwayPenaltyScore)
+	}
+	return score
+}
+
+// calendarEvent renders a match as a VEVENT stamped with the time the feed was generated. In a
+// team's feed teamID names the team the opponent is shown for; it is 0 in a competition feed.
+func calendarEvent(match models.Match, teamID uint, stamp time.Time) []string {
+	home, away := "TBD", "TBD"
+	if match.HomeTeam != nil {
+		home = match.HomeTeam.Name
+	}
+	if match.AwayTeam != nil {
+		away = match.AwayTeam.Name
+	}
+
+	summary := home + " vs " + away
+	var description []string
+	switch {
+	case teamID == match.HomeTeamID:
+		description = append(description, "Opponent: "+away+" (home)")
+	case teamID == match.AwayTeamID && teamID != 0:
+		description = append(description, "Opponent: "+home+" (away)")
+	}
+	if match.Season != nil {
+		competition := match.Season.Name
+		if match.Season.Competition != nil {
+			competition = match.Season.Competition.Name + " " + match.Season.Name
+		}
+		if match.Matchday > 0 {
+			competition += fmt.Sprintf(", matchday %d", match.Matchday)
+		}
+		description = append(description, competition)
+	}
+	if match.Status.IsDecided() && match.MatchResult != nil {
+		score := calendarScore(match.MatchResult)
+		summary = home + " " + score + " " + away
+		description = append(description, "Final score: "+score+", "+finalStatusLabel(match.MatchResult))
+	}
+	status := calendarEventStatus(match.Status)
+	if status != "CONFIRMED" {
+		description = append(description, "Match "+string(match.Status))
+	}
+
+	kickoff := *match.KickoffAt
+	lines := []string{
+		"BEGIN:VEVENT",
+		fmt.Sprintf("UID:match-%d@ayoindo", match.ID),
+		"DTSTAMP:" + icsTime(stamp),
+		"LAST-MODIFIED:" + icsTime(match.UpdatedAt),
+		fmt.Sprintf("SEQUENCE:%d", match.Sequence),
+		"DTSTART:" + icsTime(kickoff),
+		"DTEND:" + icsTime(kickoff.Add(config.MatchDuration())),
+		"SUMMARY:" + icsText(summary),
+	}
+	if match.Venue != nil {
+		lines = append(lines, "LOCATION:"+icsText(match.Venue.Name+", "+match.Venue.Address+", "+match.Venue.City))
+		if match.Venue.Latitude != nil && match.Venue.Longitude != nil {
+			lines = append(lines, fmt.Sprintf("GEO:%f;%f", *match.Venue.Latitude, *match.Venue.Longitude))
+		}
+	}
+	if len(description) > 0 {
+		lines = append(lines, "DESCRIPTION:"+icsText(strings.Join(description, "\n")))
+	}
+	return append(lines, "STATUS:"+status, "END:VEVENT")
+}
+
+// writeCalendar responds with the matches as an RFC 5545 calendar
+func writeCalendar(c *gin.Context, name string, matches []models.Match, teamID uint) {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//ayoindo//Fixtures//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:" + icsText(name),
+	}
+	stamp := time.Now()
+	for _, match := range matches {
+		lines = append(lines, calendarEvent(match, teamID, stamp)...)
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(icsFold(line))
+		b.WriteString("\r\n")
+	}
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(b.String()))
+}
+
+// GetTeamCalendar godoc
+// GET /api/teams/:id/calendar.ics — public, for calendar subscriptions
+func GetTeamCalendar(c *gin.Context) {
+	id := c.Param("id")
+	var team models.Team
+	if err := config.DB.First(&team, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Team not found")
+		return
+	}
+
+	query := calendarMatchesQuery(config.DB).
+		Where("(matches.home_team_id = ? OR matches.away_team_id = ?)", team.ID, team.ID)
+	if seasonID := c.Query("season_id"); seasonID != "" {
+		query = query.Where("matches.season_id = ?", seasonID)
+	}
+
+	var matches []models.Match
+	query.Find(&matches)
+
+	writeCalendar(c, team.Name+" fixtures", matches, team.ID)
+}
+
+// GetCompetitionCalendar godoc
+// GET /api/competitions/:id/calendar.ics — public, every match of the competition's seasons
+func GetCompetitionCalendar(c *gin.Context) {
+	id := c.Param("id")
+	var competition models.Competition
+	if err := config.DB.First(&competition, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Competition not found")
+		return
+	}
+
+	query := calendarMatchesQuery(config.DB).
+		Joins("JOIN seasons ON seasons.id = matches.season_id AND seasons.deleted_at IS NULL").
+		Where("seasons.competition_id = ?", competition.ID)
+	if seasonID := c.Query("season_id"); seasonID != "" {
+		query = query.Where("matches.season_id = ?", seasonID)
+	}
+
+	var matches []models.Match
+	query.Find(&matches)
+
+	writeCalendar(c, competition.Name+" fixtures", matches, 0)
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"ayoindo/models"
+)
+
+func TestIcsText(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{in: "Persija Jakarta vs Arema FC", want: "Persija Jakarta vs Arema FC"},
+		{in: "Stadion GBK, Jakarta", want: `Stadion GBK\, Jakarta`},
+		{in: "a;b", want: `a\;b`},
+		{in: `C:\stadium`, want: `C:\\stadium`},
+		{in: "line one\nline two", want: `line one\nline two`},
+		{in: "line one\r\nline two", want: `line one\nline two`},
+		{in: `\,`, want: `\\\,`}, // the backslash is escaped before the comma adds its own
+	}
+
+	for _, tt := range tests {
+		if got := icsText(tt.in); got != tt.want {
+			t.Errorf("icsText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestIcsFold(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{name: "short line", line: "SUMMARY:Persija Jakarta vs Arema FC"},
+		{name: "exactly the limit", line: strings.Repeat("a", icsLineLimit)},
+		{name: "one octet over", line: strings.Repeat("a", icsLineLimit+1)},
+		{name: "long ascii", line: "DESCRIPTION:" + strings.Repeat("x", 200)},
+		// 74 octets, then a 2-octet rune that would end at octet 76
+		{name: "two-byte rune at the limit", line: strings.Repeat("a", icsLineLimit-1) + "é" + "b"},
+		// a 3-octet rune straddling the limit, then more on the continuation line
+		{name: "three-byte rune across the limit", line: strings.Repeat("a", icsLineLimit-2) + "€" + strings.Repeat("€", 40)},
+		{name: "four-byte runes", line: "SUMMARY:" + strings.Repeat("⚽🏆", 30)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			folded := icsFold(tt.line)
+			parts := strings.Split(folded, "\r\n")
+			for i, part := range parts {
+				if len(part) > icsLineLimit {
+					t.Errorf("line %d has %d octets: %q", i+1, len(part), part)
+				}
+				if !utf8.ValidString(part) {
+					t.Errorf("line %d breaks a character: %q", i+1, part)
+				}
+				if i > 0 && !strings.HasPrefix(part, " ") {
+					t.Errorf("continuation line %d does not start with a space: %q", i+1, part)
+				}
+			}
+			if unfolded := strings.ReplaceAll(folded, "\r\n ", ""); unfolded != tt.line {
+				t.Errorf("unfolding gave %q, want %q", unfolded, tt.line)
+			}
+			if len(tt.line) <= icsLineLimit && folded != tt.line {
+				t.Errorf("a line within the limit was folded: %q", folded)
+			}
+		})
+	}
+}
+
+func TestCalendarEvent(t *testing.T) {
+	kickoff := time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)
+	updated := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+	stamp := time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC)
+	home, away := &models.Team{Name: "Persija Jakarta"}, &models.Team{Name: "Arema FC"}
+	match := func(status models.MatchStatus, sequence int) models.Match {
+		return models.Match{
+			ID: 7, HomeTeamID: 1, AwayTeamID: 2, HomeTeam: home, AwayTeam: away,
+			Status: status, Sequence: sequence, KickoffAt: &kickoff, UpdatedAt: updated,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		match  models.Match
+		teamID uint
+		want   []string // lines the event must contain
+	}{
+		{
+			name:  "scheduled",
+			match: match(models.MatchStatusScheduled, 0),
+			want:  []string{"UID:match-7@ayoindo", "SEQUENCE:0", "STATUS:CONFIRMED", "SUMMARY:Persija Jakarta vs Arema FC", "DTSTART:20250315T120000Z"},
+		},
+		{
+			name:   "rescheduled, in the away team's feed",
+			match:  match(models.MatchStatusScheduled, 3),
+			teamID: 2,
+			want:   []string{"SEQUENCE:3", "STATUS:CONFIRMED", "DESCRIPTION:Opponent: Persija Jakarta (away)"},
+		},
+		{
+			name:  "postponed",
+			match: match(models.MatchStatusPostponed, 1),
+			want:  []string{"SEQUENCE:1", "STATUS:TENTATIVE", `DESCRIPTION:Match postponed`},
+		},
+		{
+			name:  "abandoned",
+			match: match(models.MatchStatusAbandoned, 2),
+			want:  []string{"STATUS:TENTATIVE"},
+		},
+		{
+			name:  "cancelled",
+			match: match(models.MatchStatusCancelled, 2),
+			want:  []string{"SEQUENCE:2", "STATUS:CANCELLED", `DESCRIPTION:Match cancelled`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := calendarEvent(tt.match, tt.teamID, stamp)
+			if lines[0] != "BEGIN:VEVENT" || lines[len(lines)-1] != "END:VEVENT" {
+				t.Fatalf("not a VEVENT: %v", lines)
+			}
+			has := make(map[string]bool)
+			for _, line := range lines {
+				has[line] = true
+			}
+			want := append([]string{"DTSTAMP:20250310T080000Z", "LAST-MODIFIED:20250301T093000Z"}, tt.want...)
+			for _, line := range want {
+				if !has[line] {
+					t.Errorf("missing %q in %v", line, lines)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"net/http"
+
+	"ayoindo/config"
+	"ayoindo/models"
+	"ayoindo/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CompetitionInput struct {
+	Name        string                   `json:"name" binding:"required,min=2,max=100"`
+	Format      models.CompetitionFormat `json:"format" binding:"required"`
+	Description string                   `json:"description"`
+	Tier        int                      `json:"tier" binding:"min=0,max=20"` // 1 = top division, 0 = not part of a pyramid
+
+	// Suspension rules, match length and time zone; omitted fields keep their current value
+	YellowCardThreshold *int    `json:"yellow_card_threshold" binding:"omitempty,min=0,max=20"`
+	YellowCardBan       *int    `json:"yellow_card_ban" binding:"omitempty,min=1,max=20"`
+	RedCardBan          *int    `json:"red_card_ban" binding:"omitempty,min=1,max=20"`
+	MatchLength         *int    `json:"match_length" binding:"omitempty,min=20,max=90"` // regulation minutes, default 90
+	Timezone            *string `json:"timezone" binding:"omitempty,timezone"`          // IANA name, default Asia/Jakarta
+}
+
+// applyCompetitionRules copies the optional suspension rules, match length and time zone onto the competition
+func applyCompetitionRules(competition *models.Competition, input CompetitionInput) {
+	if input.YellowCardThreshold != nil {
+		competition.YellowCardThreshold = *input.YellowCardThreshold
+	}
+	if input.YellowCardBan != nil {
+		competition.YellowCardBan = *input.YellowCardBan
+	}
+	if input.RedCardBan != nil {
+		competition.RedCardBan = *input.RedCardBan
+	}
+	if input.MatchLength != nil {
+		competition.MatchLength = *input.MatchLength
+	}
+	if input.Timezone != nil {
+		competition.Timezone = *input.Timezone
+	}
+}
+
+func isValidCompetitionFormat(format models.CompetitionFormat) bool {
+	switch format {
+	case models.CompetitionFormatLeague, models.CompetitionFormatCup, models.CompetitionFormatGroupKnockout:
+		return true
+	}
+	return false
+}
+
+// GetAllCompetitions godoc
+// GET /api/competitions
+func GetAllCompetitions(c *gin.Context) {
+	var competitions []models.Competition
+	query := config.DB.Model(&models.Competition{})
+
+	if format := c.Query("format"); format != "" {
+		query = query.Where("format = ?", format)
+	}
+
+	var total int64
+	query.Count(&total)
+	query.Find(&competitions)
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Competitions retrieved successfully",
+		"data":    competitions,
+		"total":   total,
+	})
+}
+
+// GetCompetitionByID godoc
+// GET /api/competitions/:id
+func GetCompetitionByID(c *gin.Context) {
+	id := c.Param("id")
+	var competition models.Competition
+
+	if err := config.DB.Preload("Seasons").First(&competition, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Competition not found")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Competition retrieved successfully", competition)
+}
+
+// CreateCompetition godoc
+// POST /api/competitions
+func CreateCompetition(c *gin.Context) {
+	var input CompetitionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	if !isValidCompetitionFormat(input.Format) {
+		utils.ValidationErrorResponse(c, "Invalid format. Must be one of: league, cup, group_knockout")
+		return
+	}
+
+	competition := models.Competition{
+		Name:                input.Name,
+		Format:              input.Format,
+		Description:         input.Description,
+		Tier:                input.Tier,
+		YellowCardThreshold: models.DefaultYellowCardThreshold,
+		YellowCardBan:       models.DefaultYellowCardBan,
+		RedCardBan:          models.DefaultRedCardBan,
+		MatchLength:         models.DefaultMatchLength,
+		Timezone:            models.DefaultTimezone,
+	}
+	applyCompetitionRules(&competition, input)
+
+	if err := config.DB.Create(&competition).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create competition")
+		return
+	}
+
+	// GORM replaces zero values with the column default on insert, so write the threshold explicitly
+	config.DB.Model(&competition).Update("yellow_card_threshold", competition.YellowCardThreshold)
+
+	utils.SuccessResponse(c, http.StatusCreated, "Competition created successfully", competition)
+}
+
+// UpdateCompetition godoc
+// PUT /api/competitions/:id
+func UpdateCompetition(c *gin.Context) {
+	id := c.Param("id")
+	var competition models.Competition
+
+	if err := config.DB.First(&competition, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Competition not found")
+		return
+	}
+
+	var input CompetitionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	if !isValidCompetitionFormat(input.Format) {
+		utils.ValidationErrorResponse(c, "Invalid format. Must be one of: league, cup, group_knockout")
+		return
+	}
+
+	renamed := competition.Name != input.Name
+
+	competition.Name = input.Name
+	competition.Format = input.Format
+	competition.Description = input.Description
+	competition.Tier = input.Tier
+	applyCompetitionRules(&competition, input)
+
+	if err := config.DB.Save(&competition).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update competition")
+		return
+	}
+
+	// Calendar events show the competition's name
+	if renamed {
+		bumpCalendarSequence(config.DB, "season_id IN (?)",
+			config.DB.Model(&models.Season{}).Select("id").Where("competition_id = ?", competition.ID))
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Competition updated successfully", competition)
+}
+
+// DeleteCompetition godoc
+// DELETE /api/competitions/:id — soft delete
+func DeleteCompetition(c *gin.Context) {
+	id := c.Param("id")
+	var competition models.Competition
+
+	if err := config.DB.First(&competition, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Competition not found")
+		return
+	}
+
+	if err := config.DB.Delete(&competition).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete competition")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Competition deleted successfully", nil)
+}
@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"ayoindo/config"
+	"ayoindo/models"
+	"ayoindo/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm/clause"
+)
+
+type NextSeasonInput struct {
+	Name      string `json:"name" binding:"required,min=2,max=100"`
+	StartDate string `json:"start_date" binding:"required,datetime=2006-01-02"` // YYYY-MM-DD
+	EndDate   string `json:"end_date" binding:"required,datetime=2006-01-02"`   // YYYY-MM-DD
+}
+
+type RolloverInput struct {
+	SeasonIDs  []uint          `json:"season_ids" binding:"required,min=1"` // one finished season per division
+	Promoted   int             `json:"promoted" binding:"min=0"`            // teams moving up from every division but the top one
+	Relegated  int             `json:"relegated" binding:"min=0"`           // teams moving down from every division but the bottom one
+	NextSeason NextSeasonInput `json:"next_season" binding:"required"`
+}
+
+type RolloverResult struct {
+	History     []models.TeamDivisionHistory `json:"history"`
+	NextSeasons []models.Season              `json:"next_seasons"`
+}
+
+// divisionTierError explains why divisions of the given tiers cannot be rolled over together, or
+// returns "". Teams only move between adjacent divisions, so the tiers must be distinct and contiguous.
+func divisionTierError(tiers []int) string {
+	sorted := append([]int{}, tiers...)
+	sort.Ints(sorted)
+	for i := 1; i < len(sorted); i++ {
+		switch {
+		case sorted[i] == sorted[i-1]:
+			return "Seasons must belong to different divisions"
+		case sorted[i] != sorted[i-1]+1:
+			return fmt.Sprintf("Division tiers must be contiguous: tier %d is missing between %d and %d", sorted[i-1]+1, sorted[i-1], sorted[i])
+		}
+	}
+	return ""
+}
+
+// RolloverDivisions godoc
+// POST /api/seasons/rollover — promotion and relegation between divisions at season end
+func RolloverDivisions(c *gin.Context) {
+	var input RolloverInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	if input.NextSeason.EndDate < input.NextSeason.StartDate {
+		utils.ValidationErrorResponse(c, "End date cannot be before start date")
+		return
+	}
+
+	// Load the finished seasons and order them from the top division down
+	var seasons []models.Season
+	var tiers []int
+	for _, id := range input.SeasonIDs {
+		var season models.Season
+		if err := config.DB.Preload("Competition").Preload("Teams").First(&season, id).Error; err != nil {
+			utils.ErrorResponse(c, http.StatusNotFound, "Season not found")
+			return
+		}
+		if season.Competition == nil || season.Competition.Tier < 1 {
+			utils.ValidationErrorResponse(c, "Every season must belong to a competition with a division tier")
+			return
+		}
+		tiers = append(tiers, season.Competition.Tier)
+
+		var unfinished int64
+		config.DB.Model(&models.Match{}).
+			Where("season_id = ? AND status IN ?", season.ID, models.OpenMatchStatuses).
+			Count(&unfinished)
+		if unfinished > 0 {
+			utils.ValidationErrorResponse(c, "All matches of season "+season.Name+" must be completed or cancelled")
+			return
+		}
+
+		seasons = append(seasons, season)
+	}
+	if msg := divisionTierError(tiers); msg != "" {
+		utils.ValidationErrorResponse(c, msg)
+		return
+	}
+	sort.Slice(seasons, func(i, j int) bool {
+		return seasons[i].Competition.Tier < seasons[j].Competition.Tier
+	})
+
+	// Final tables decide who moves; incoming teams are collected per division
+	incoming := make([][]uint, len(seasons))
+	var history []models.TeamDivisionHistory
+
+	for i, season := range seasons {
+		table := computeStandings(season.Teams, seasonCompletedMatches(config.DB, season.ID), seasonStandingsRules(season))
+
+		promoted, relegated := 0, 0
+		if i > 0 {
+			promoted = input.Promoted
+		}
+		if i < len(seasons)-1 {
+			relegated = input.Relegated
+		}
+		if promoted+relegated > len(table) {
+			utils.ValidationErrorResponse(c, "Season "+season.Name+" has fewer teams than places to promote and relegate")
+			return
+		}
+
+		for pos, row := range table {
+			movement := models.DivisionStayed
+			switch {
+			case pos < promoted:
+				movement = models.DivisionPromoted
+				incoming[i-1] = append(incoming[i-1], row.TeamID)
+			case pos >= len(table)-relegated:
+				movement = models.DivisionRelegated
+				incoming[i+1] = append(incoming[i+1], row.TeamID)
+			default:
+				incoming[i] = append(incoming[i], row.TeamID)
+			}
+			history = append(history, models.TeamDivisionHistory{
+				TeamID:        row.TeamID,
+				SeasonID:      season.ID,
+				CompetitionID: season.CompetitionID,
+				Tier:          season.Competition.Tier,
+				FinalPosition: row.Position,
+				Movement:      movement,
+			})
+		}
+	}
+
+	tx := config.DB.Begin()
+
+	// Lock the finished seasons, in id order, so concurrent rollovers of the same season run one
+	// after the other and the second one sees the history of the first
+	lockIDs := append([]uint{}, input.SeasonIDs...)
+	sort.Slice(lockIDs, func(i, j int) bool { return lockIDs[i] < lockIDs[j] })
+	var locked []models.Season
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id IN ?", lockIDs).Order("id ASC").Find(&locked).Error; err != nil {
+		tx.Rollback()
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to roll over divisions")
+		return
+	}
+	for _, season := range seasons {
+		var rolled int64
+		tx.Model(&models.TeamDivisionHistory{}).Where("season_id = ?", season.ID).Count(&rolled)
+		if rolled > 0 {
+			tx.Rollback()
+			utils.ErrorResponse(c, http.StatusConflict, "Season "+season.Name+" has already been rolled over")
+			return
+		}
+	}
+
+	if len(history) > 0 {
+		if err := tx.Create(&history).Error; err != nil {
+			tx.Rollback()
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to save division history")
+			return
+		}
+	}
+
+	// Next season of every division keeps the standings configuration of the finished one
+	nextSeasons := make([]models.Season, len(seasons))
+	for i, season := range seasons {
+		teams, _ := loadSeasonTeams(incoming[i])
+		next := models.Season{
+			CompetitionID:        season.CompetitionID,
+			Name:                 input.NextSeason.Name,
+			StartDate:            input.NextSeason.StartDate,
+			EndDate:              input.NextSeason.EndDate,
+			Teams:                teams,
+			PointsForWin:         season.PointsForWin,
+			PointsForDraw:        season.PointsForDraw,
+			TieBreakers:          season.TieBreakers,
+			QualifiersPerGroup:   season.QualifiersPerGroup,
+			BestThirdPlaced:      season.BestThirdPlaced,
+			KnockoutIntervalDays: season.KnockoutIntervalDays,
+			KnockoutMatchTime:    season.KnockoutMatchTime,
+		}
+		if err := tx.Create(&next).Error; err != nil {
+			tx.Rollback()
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create next season")
+			return
+		}
+		// GORM replaces zero values with the column default on insert, so write points explicitly
+		if err := tx.Model(&next).Updates(map[string]interface{}{
+			"points_for_win":  next.PointsForWin,
+			"points_for_draw": next.PointsForDraw,
+		}).Error; err != nil {
+			tx.Rollback()
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create next season")
+			return
+		}
+		nextSeasons[i] = next
+	}
+
+	tx.Commit()
+
+	for i := range nextSeasons {
+		config.DB.Preload("Competition").Preload("Teams").First(&nextSeasons[i], nextSeasons[i].ID)
+	}
+	utils.SuccessResponse(c, http.StatusCreated, "Divisions rolled over successfully", RolloverResult{
+		History:     history,
+		NextSeasons: nextSeasons,
+	})
+}
+
+// GetTeamDivisionHistory godoc
+// GET /api/teams/:id/divisions
+func GetTeamDivisionHistory(c *gin.Context) {
+	id := c.Param("id")
+	var team models.Team
+	if err := config.DB.First(&team, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Team not found")
+		return
+	}
+
+	var history []models.TeamDivisionHistory
+	config.DB.
+		Preload("Season").
+		Preload("Season.Competition").
+		Joins("JOIN seasons ON seasons.id = team_division_histories.season_id").
+		Where("team_division_histories.team_id = ?", team.ID).
+		Order("seasons.start_date ASC").
+		Find(&history)
+
+	utils.SuccessResponse(c, http.StatusOK, "Division history retrieved successfully", history)
+}
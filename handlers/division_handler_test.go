@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDivisionTierError(t *testing.T) {
+	tests := []struct {
+		name      string
+		tiers     []int
+		wantError string // substring, "" for valid tiers
+	}{
+		{name: "single division", tiers: []int{1}},
+		{name: "contiguous", tiers: []int{1, 2, 3}},
+		{name: "contiguous in any order", tiers: []int{3, 1, 2}},
+		{name: "lower divisions only", tiers: []int{2, 3}},
+		{name: "duplicate tier", tiers: []int{1, 2, 2}, wantError: "different divisions"},
+		{name: "gap", tiers: []int{1, 3}, wantError: "tier 2 is missing"},
+		{name: "gap below", tiers: []int{4, 1, 2}, wantError: "tier 3 is missing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := divisionTierError(tt.tiers)
+			if tt.wantError == "" {
+				if got != "" {
+					t.Errorf("got error %q, want none", got)
+				}
+				return
+			}
+			if !strings.Contains(got, tt.wantError) {
+				t.Errorf("got error %q, want it to mention %q", got, tt.wantError)
+			}
+		})
+	}
+}
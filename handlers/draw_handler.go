@@ -0,0 +1,306 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	"ayoindo/config"
+	"ayoindo/models"
+	"ayoindo/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Draw constraint types
+const (
+	DrawConstraintSameCity      = "same_city"      // at most max_per_group teams from one city in a group
+	DrawConstraintSeparateTeams = "separate_teams" // the listed teams end up in different groups
+)
+
+// maxDrawSteps bounds the backtracking search so impossible constraint sets fail fast
+const maxDrawSteps = 200000
+
+var (
+	errNoValidDraw   = errors.New("no valid draw exists for these pots and constraints")
+	errDrawStepLimit = errors.New("the draw search gave up before finding a valid draw")
+)
+
+type DrawConstraintInput struct {
+	Type        string `json:"type" binding:"required"`
+	MaxPerGroup int    `json:"max_per_group" binding:"min=0"` // same_city, defaults to 1
+	TeamIDs     []uint `json:"team_ids"`                      // separate_teams
+}
+
+type DrawInput struct {
+	GroupNames  []string              `json:"group_names" binding:"required,min=2"`
+	Pots        [][]uint              `json:"pots" binding:"required,min=1"` // pot 1 first
+	Constraints []DrawConstraintInput `json:"constraints" binding:"dive"`
+	Seed        *int64                `json:"seed"` // random when omitted; the same seed and input give the same draw
+	DryRun      bool                  `json:"dry_run"`
+}
+
+type DrawResult struct {
+	Seed   int64                `json:"seed"`
+	Groups []models.SeasonGroup `json:"groups"`
+}
+
+type drawTeam struct {
+	ID   uint
+	City string
+	Pot  int
+}
+
+// runGroupDraw assigns the teams of every pot to groups. Pots are drawn in order and each pot's
+// teams in an order shuffled by the seed; every team goes to the first group, in group order,
+// that keeps the draw valid. Backtracking guarantees that a team is never placed in a way that
+// leaves the remaining teams without a valid group. Each group takes at most one team per pot.
+// It returns errNoValidDraw when the search proves no draw exists and errDrawStepLimit when it
+// stops after maxDrawSteps without an answer.
+func runGroupDraw(pots [][]drawTeam, groupCount int, constraints []DrawConstraintInput, seed int64) ([][]drawTeam, error) {
+	rng := rand.New(rand.NewSource(seed))
+
+	var order []drawTeam
+	total := 0
+	for _, pot := range pots {
+		shuffled := append([]drawTeam{}, pot...)
+		rng.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+		order = append(order, shuffled...)
+		total += len(pot)
+	}
+	capacity := (total + groupCount - 1) / groupCount
+
+	// separate_teams constraints are checked through a team -> constraint index lookup
+	separated := make(map[uint][]int)
+	for i, con := range constraints {
+		if con.Type == DrawConstraintSeparateTeams {
+			for _, id := range con.TeamIDs {
+				separated[id] = append(separated[id], i)
+			}
+		}
+	}
+
+	groups := make([][]drawTeam, groupCount)
+
+	fits := func(t drawTeam, g []drawTeam) bool {
+		if len(g) >= capacity {
+			return false
+		}
+		for _, other := range g {
+			if other.Pot == t.Pot {
+				return false
+			}
+			for _, ci := range separated[t.ID] {
+				for _, oi := range separated[other.ID] {
+					if ci == oi {
+						return false
+					}
+				}
+			}
+		}
+		for _, con := range constraints {
+			if con.Type != DrawConstraintSameCity {
+				continue
+			}
+			limit := con.MaxPerGroup
+			if limit == 0 {
+				limit = 1
+			}
+			sameCity := 0
+			for _, other := range g {
+				if other.City == t.City {
+					sameCity++
+				}
+			}
+			if sameCity >= limit {
+				return false
+			}
+		}
+		return true
+	}
+
+	steps := 0
+	var place func(i int) bool
+	place = func(i int) bool {
+		if i == len(order) {
+			return true
+		}
+		steps++
+		if steps > maxDrawSteps {
+			return false
+		}
+		t := order[i]
+		for gi := range groups {
+			if !fits(t, groups[gi]) {
+				continue
+			}
+			groups[gi] = append(groups[gi], t)
+			if place(i + 1) {
+				return true
+			}
+			groups[gi] = groups[gi][:len(groups[gi])-1]
+		}
+		return false
+	}
+
+	if !place(0) {
+		if steps > maxDrawSteps {
+			return nil, errDrawStepLimit
+		}
+		return nil, errNoValidDraw
+	}
+	return groups, nil
+}
+
+// DrawSeasonGroups godoc
+// POST /api/seasons/:id/draw — draws the teams of the pots into groups and saves them
+func DrawSeasonGroups(c *gin.Context) {
+	id := c.Param("id")
+	var season models.Season
+	if err := config.DB.First(&season, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Season not found")
+		return
+	}
+	if !isGroupKnockoutSeason(config.DB, season) {
+		utils.ValidationErrorResponse(c, groupKnockoutOnly)
+		return
+	}
+
+	var input DrawInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	for _, con := range input.Constraints {
+		switch con.Type {
+		case DrawConstraintSameCity:
+		case DrawConstraintSeparateTeams:
+			if len(con.TeamIDs) < 2 {
+				utils.ValidationErrorResponse(c, "A separate_teams constraint needs at least two team_ids")
+				return
+			}
+		default:
+			utils.ValidationErrorResponse(c, "Invalid constraint type. Must be one of: same_city, separate_teams")
+			return
+		}
+	}
+
+	// Load the pots, every team must be registered in the season and appear once
+	seen := make(map[uint]bool)
+	pots := make([][]drawTeam, 0, len(input.Pots))
+	for i, potIDs := range input.Pots {
+		if len(potIDs) > len(input.GroupNames) {
+			utils.ValidationErrorResponse(c, "A pot cannot hold more teams than there are groups")
+			return
+		}
+		var pot []drawTeam
+		for _, teamID := range potIDs {
+			if seen[teamID] {
+				utils.ValidationErrorResponse(c, "A team cannot be in more than one pot")
+				return
+			}
+			seen[teamID] = true
+
+			var team models.Team
+			if err := config.DB.First(&team, teamID).Error; err != nil {
+				utils.ErrorResponse(c, http.StatusNotFound, "Team not found")
+				return
+			}
+			if !seasonHasTeam(season.ID, team.ID) {
+				utils.ValidationErrorResponse(c, "All teams must be registered in the season")
+				return
+			}
+			pot = append(pot, drawTeam{
+				ID:   team.ID,
+				City: strings.ToLower(strings.TrimSpace(team.City)),
+				Pot:  i,
+			})
+		}
+		pots = append(pots, pot)
+	}
+
+	var groupMatches int64
+	config.DB.Model(&models.Match{}).Where("season_id = ? AND group_id IS NOT NULL", season.ID).Count(&groupMatches)
+	if groupMatches > 0 {
+		utils.ErrorResponse(c, http.StatusConflict, "Groups cannot be redrawn after group fixtures have been created")
+		return
+	}
+
+	seed := time.Now().UnixNano()
+	if input.Seed != nil {
+		seed = *input.Seed
+	}
+
+	drawn, err := runGroupDraw(pots, len(input.GroupNames), input.Constraints, seed)
+	if errors.Is(err, errDrawStepLimit) {
+		utils.ErrorResponseWithData(c, http.StatusServiceUnavailable,
+			fmt.Sprintf("The draw search gave up after %d steps without finding a valid draw; retry with another seed or fewer constraints", maxDrawSteps),
+			gin.H{"seed": seed})
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusUnprocessableEntity, "No valid draw exists for these pots and constraints")
+		return
+	}
+
+	groups := make([]models.SeasonGroup, len(drawn))
+	for gi, members := range drawn {
+		var teamIDs []uint
+		for _, t := range members {
+			teamIDs = append(teamIDs, t.ID)
+		}
+		teams, _ := loadSeasonTeams(teamIDs)
+		groups[gi] = models.SeasonGroup{
+			SeasonID: season.ID,
+			Name:     input.GroupNames[gi],
+			Teams:    teams,
+		}
+	}
+
+	if input.DryRun {
+		utils.SuccessResponse(c, http.StatusOK, "Draw preview generated successfully", DrawResult{Seed: seed, Groups: groups})
+		return
+	}
+
+	// The draw replaces any previous groups of the season
+	tx := config.DB.Begin()
+
+	var previous []models.SeasonGroup
+	tx.Where("season_id = ?", season.ID).Find(&previous)
+	for i := range previous {
+		if err := tx.Model(&previous[i]).Association("Teams").Clear(); err != nil {
+			tx.Rollback()
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to clear previous groups")
+			return
+		}
+		if err := tx.Delete(&previous[i]).Error; err != nil {
+			tx.Rollback()
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to clear previous groups")
+			return
+		}
+	}
+
+	for i := range groups {
+		if err := tx.Create(&groups[i]).Error; err != nil {
+			tx.Rollback()
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to save groups")
+			return
+		}
+	}
+
+	season.DrawSeed = &seed
+	if err := tx.Save(&season).Error; err != nil {
+		tx.Rollback()
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to save draw seed")
+		return
+	}
+
+	tx.Commit()
+
+	config.DB.Preload("Teams").Where("season_id = ?", season.ID).Order("id ASC").Find(&groups)
+	utils.SuccessResponse(c, http.StatusCreated, "Draw completed successfully", DrawResult{Seed: seed, Groups: groups})
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import "testing"
+
+// drawPots builds pots of teams numbered pot*10+i; cities[pot][i] is the city of each team
+func drawPots(cities [][]string) [][]drawTeam {
+	pots := make([][]drawTeam, len(cities))
+	for p, potCities := range cities {
+		for i, city := range potCities {
+			pots[p] = append(pots[p], drawTeam{ID: uint((p+1)*10 + i), City: city, Pot: p + 1})
+		}
+	}
+	return pots
+}
+
+func TestRunGroupDraw(t *testing.T) {
+	pots := drawPots([][]string{
+		{"Jakarta", "Bandung", "Surabaya", "Medan"},
+		{"Jakarta", "Bandung", "Malang", "Bali"},
+		{"Jakarta", "Solo", "Malang", "Makassar"},
+		{"Jakarta", "Padang", "Bogor", "Bekasi"},
+	})
+
+	tests := []struct {
+		name        string
+		groupCount  int
+		constraints []DrawConstraintInput
+		check       func(t *testing.T, groups [][]drawTeam)
+	}{
+		{name: "one team per pot", groupCount: 4},
+		{
+			name:        "same city",
+			groupCount:  4,
+			constraints: []DrawConstraintInput{{Type: DrawConstraintSameCity}},
+			check: func(t *testing.T, groups [][]drawTeam) {
+				for gi, g := range groups {
+					cities := make(map[string]bool)
+					for _, team := range g {
+						if cities[team.City] {
+							t.Errorf("group %d has two teams from %s", gi+1, team.City)
+						}
+						cities[team.City] = true
+					}
+				}
+			},
+		},
+		{
+			name:        "separate teams",
+			groupCount:  4,
+			constraints: []DrawConstraintInput{{Type: DrawConstraintSeparateTeams, TeamIDs: []uint{10, 21, 32, 43}}},
+			check: func(t *testing.T, groups [][]drawTeam) {
+				for gi, g := range groups {
+					separated := 0
+					for _, team := range g {
+						if team.ID == 10 || team.ID == 21 || team.ID == 32 || team.ID == 43 {
+							separated++
+						}
+					}
+					if separated > 1 {
+						t.Errorf("group %d has %d of the separated teams", gi+1, separated)
+					}
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups, err := runGroupDraw(pots, tt.groupCount, tt.constraints, 42)
+			if err != nil {
+				t.Fatalf("the draw failed: %v", err)
+			}
+			if len(groups) != tt.groupCount {
+				t.Fatalf("got %d groups, want %d", len(groups), tt.groupCount)
+			}
+
+			drawn := make(map[uint]bool)
+			for gi, g := range groups {
+				pots := make(map[int]bool)
+				for _, team := range g {
+					if drawn[team.ID] {
+						t.Errorf("team %d is drawn twice", team.ID)
+					}
+					drawn[team.ID] = true
+					if pots[team.Pot] {
+						t.Errorf("group %d has two teams from pot %d", gi+1, team.Pot)
+					}
+					pots[team.Pot] = true
+				}
+			}
+			if len(drawn) != 16 {
+				t.Errorf("got %d teams drawn, want 16", len(drawn))
+			}
+			if tt.check != nil {
+				tt.check(t, groups)
+			}
+		})
+	}
+}
+
+func TestRunGroupDrawIsReproducible(t *testing.T) {
+	pots := drawPots([][]string{{"A", "B", "C", "D"}, {"E", "F", "G", "H"}})
+
+	first, err := runGroupDraw(pots, 4, nil, 7)
+	if err != nil {
+		t.Fatalf("the draw failed: %v", err)
+	}
+	second, _ := runGroupDraw(pots, 4, nil, 7)
+	for gi := range first {
+		for i := range first[gi] {
+			if first[gi][i].ID != second[gi][i].ID {
+				t.Fatalf("the same seed gave different draws: %v and %v", first, second)
+			}
+		}
+	}
+}
+
+func TestRunGroupDrawImpossibleConstraints(t *testing.T) {
+	pots := drawPots([][]string{{"A", "B"}, {"C", "D"}, {"E", "F"}})
+	constraints := []DrawConstraintInput{{Type: DrawConstraintSeparateTeams, TeamIDs: []uint{10, 20, 30}}}
+
+	if _, err := runGroupDraw(pots, 2, constraints, 1); err != errNoValidDraw {
+		t.Errorf("got %v, want errNoValidDraw for three separated teams in two groups", err)
+	}
+}
+
+func TestRunGroupDrawStepLimit(t *testing.T) {
+	// Twelve groups cannot separate the twelve pot 4 teams and a pot 3 team, but that only shows
+	// when pot 4 is drawn, so the search runs out of steps rearranging the first three pots
+	cities := make([][]string, 4)
+	for p := range cities {
+		for i := 0; i < 12; i++ {
+			cities[p] = append(cities[p], "city")
+		}
+	}
+	pots := drawPots(cities)
+	constraints := []DrawConstraintInput{{Type: DrawConstraintSeparateTeams, TeamIDs: []uint{40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 30}}}
+
+	if _, err := runGroupDraw(pots, 12, constraints, 1); err != errDrawStepLimit {
+		t.Errorf("got %v, want errDrawStepLimit", err)
+	}
+}
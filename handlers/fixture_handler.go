@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"ayoindo/config"
+	"ayoindo/models"
+	"ayoindo/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FixtureGenerateInput struct {
+	StartDate    string   `json:"start_date" binding:"required,datetime=2006-01-02"`          // YYYY-MM-DD, date of matchday 1
+	IntervalDays int      `json:"interval_days" binding:"required,min=1,max=60"`              // days between matchdays
+	KickoffSlots []string `json:"kickoff_slots" binding:"required,min=1,dive,datetime=15:04"` // HH:MM, assigned in turn within a matchday
+	DoubleRound  bool     `json:"double_round"`                                               // play every pairing home and away
+	DryRun       bool     `json:"dry_run"`                                                    // preview only, nothing is saved
+}
+
+type fixturePairing struct {
+	HomeTeamID uint
+	AwayTeamID uint
+	GroupID    *uint
+}
+
+// generateRoundRobin builds a single round-robin schedule with the circle (Berger) method.
+// Each inner slice is one matchday. With an odd number of teams one team rests every matchday.
+// Home and away alternate so every team has at most one break per half.
+func generateRoundRobin(teamIDs []uint) [][]fixturePairing {
+	ids := append([]uint{}, teamIDs...)
+	if len(ids)%2 == 1 {
+		ids = append(ids, 0) // bye
+	}
+	n := len(ids)
+	m := n - 1
+
+	var rounds [][]fixturePairing
+	for r := 0; r < m; r++ {
+		var pairs []fixturePairing
+
+		// The last team stays fixed and alternates home and away against the rotating team
+		if r%2 == 0 {
+			pairs = append(pairs, fixturePairing{HomeTeamID: ids[r], AwayTeamID: ids[n-1]})
+		} else {
+			pairs = append(pairs, fixturePairing{HomeTeamID: ids[n-1], AwayTeamID: ids[r]})
+		}
+
+		for k := 1; k < n/2; k++ {
+			a := ids[(r+k)%m]
+			b := ids[(r-k+m)%m]
+			if k%2 == 1 {
+				pairs = append(pairs, fixturePairing{HomeTeamID: b, AwayTeamID: a})
+			} else {
+				pairs = append(pairs, fixturePairing{HomeTeamID: a, AwayTeamID: b})
+			}
+		}
+
+		var played []fixturePairing
+		for _, p := range pairs {
+			if p.HomeTeamID != 0 && p.AwayTeamID != 0 {
+				played = append(played, p)
+			}
+		}
+		rounds = append(rounds, played)
+	}
+	return rounds
+}
+
+// seasonRoundRobin schedules the teams in ID order, mirrored into a second half for a double round-robin
+func seasonRoundRobin(teams []models.Team, doubleRound bool) [][]fixturePairing {
+	teamIDs := make([]uint, 0, len(teams))
+	for _, t := range teams {
+		teamIDs = append(teamIDs, t.ID)
+	}
+	sort.Slice(teamIDs, func(i, j int) bool { return teamIDs[i] < teamIDs[j] })
+
+	rounds := generateRoundRobin(teamIDs)
+	if doubleRound {
+		// Second half mirrors the first with home and away swapped
+		firstHalf := rounds
+		for _, round := range firstHalf {
+			var mirrored []fixturePairing
+			for _, p := range round {
+				mirrored = append(mirrored, fixturePairing{HomeTeamID: p.AwayTeamID, AwayTeamID: p.HomeTeamID})
+			}
+			rounds = append(rounds, mirrored)
+		}
+	}
+	return rounds
+}
+
+// buildFixtureMatches turns matchdays into scheduled matches using the date and slot settings,
+// read in the given time zone
+func buildFixtureMatches(seasonID uint, rounds [][]fixturePairing, input FixtureGenerateInput, loc *time.Location) []models.Match {
+	start, _ := time.Parse("2006-01-02", input.StartDate)
+
+	var matches []models.Match
+	for i, round := range rounds {
+		matchday := i + 1
+		date := start.AddDate(0, 0, (matchday-1)*input.IntervalDays).Format("2006-01-02")
+		for j, p := range round {
+			sid := seasonID
+			match := models.Match{
+				HomeTeamID: p.HomeTeamID,
+				AwayTeamID: p.AwayTeamID,
+				SeasonID:   &sid,
+				GroupID:    p.GroupID,
+				Matchday:   matchday,
+				Status:     models.MatchStatusScheduled,
+			}
+			kickoff, _ := parseLocalKickoff(date, input.KickoffSlots[j%len(input.KickoffSlots)], loc)
+			setKickoff(&match, kickoff, loc)
+			matches = append(matches, match)
+		}
+	}
+	return matches
+}
+
+// GenerateSeasonFixtures godoc
+// POST /api/seasons/:id/fixtures/generate
+func GenerateSeasonFixtures(c *gin.Context) {
+	id := c.Param("id")
+	var season models.Season
+
+	if err := config.DB.Preload("Teams").Preload("Groups").Preload("Groups.Teams").First(&season, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Season not found")
+		return
+	}
+
+	var input FixtureGenerateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	if len(season.Teams) < 2 {
+		utils.ValidationErrorResponse(c, "At least two teams must be registered in the season")
+		return
+	}
+
+	var existing int64
+	config.DB.Model(&models.Match{}).Where("season_id = ?", season.ID).Count(&existing)
+	if existing > 0 {
+		utils.ErrorResponse(c, http.StatusConflict, "Season already has fixtures")
+		return
+	}
+
+	teamsByID := make(map[uint]models.Team)
+	for _, t := range season.Teams {
+		teamsByID[t.ID] = t
+	}
+
+	// A season with groups plays a round-robin inside every group, all groups sharing the matchdays
+	var rounds [][]fixturePairing
+	if len(season.Groups) > 0 {
+		for _, g := range season.Groups {
+			groupID := g.ID
+			groupRounds := seasonRoundRobin(g.Teams, input.DoubleRound)
+			for i, round := range groupRounds {
+				if i == len(rounds) {
+					rounds = append(rounds, nil)
+				}
+				for _, p := range round {
+					p.GroupID = &groupID
+					rounds[i] = append(rounds[i], p)
+				}
+			}
+		}
+	} else {
+		rounds = seasonRoundRobin(season.Teams, input.DoubleRound)
+	}
+
+	matches := buildFixtureMatches(season.ID, rounds, input, kickoffLocation(config.DB, nil, &season.ID))
+
+	// Teams of the season may already play elsewhere, e.g. in another competition
+	if !rejectScheduleConflicts(c, config.DB, matches) {
+		return
+	}
+
+	if input.DryRun {
+		for i := range matches {
+			home, away := teamsByID[matches[i].HomeTeamID], teamsByID[matches[i].AwayTeamID]
+			matches[i].HomeTeam = &home
+			matches[i].AwayTeam = &away
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"success": true,
+			"message": "Fixture preview generated successfully",
+			"data":    matches,
+			"total":   len(matches),
+		})
+		return
+	}
+
+	tx := config.DB.Begin()
+	if err := tx.Create(&matches).Error; err != nil {
+		tx.Rollback()
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create fixtures")
+		return
+	}
+	tx.Commit()
+
+	config.DB.Preload("HomeTeam").Preload("AwayTeam").
+		Where("season_id = ?", season.ID).
+		Order("matchday ASC, kickoff_at ASC, id ASC").
+		Find(&matches)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"message": "Fixtures generated successfully",
+		"data":    matches,
+		"total":   len(matches),
+	})
+}
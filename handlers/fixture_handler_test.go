@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"testing"
+
+	"ayoindo/models"
+)
+
+func TestGenerateRoundRobin(t *testing.T) {
+	tests := []struct {
+		name        string
+		teams       int
+		wantRounds  int
+		wantPerDay  int
+		wantResting int // teams without a match on each matchday
+	}{
+		{name: "two teams", teams: 2, wantRounds: 1, wantPerDay: 1},
+		{name: "three teams", teams: 3, wantRounds: 3, wantPerDay: 1, wantResting: 1},
+		{name: "four teams", teams: 4, wantRounds: 3, wantPerDay: 2},
+		{name: "five teams", teams: 5, wantRounds: 5, wantPerDay: 2, wantResting: 1},
+		{name: "eighteen teams", teams: 18, wantRounds: 17, wantPerDay: 9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids := make([]uint, tt.teams)
+			for i := range ids {
+				ids[i] = uint(i + 1)
+			}
+			rounds := generateRoundRobin(ids)
+
+			if len(rounds) != tt.wantRounds {
+				t.Fatalf("got %d matchdays, want %d", len(rounds), tt.wantRounds)
+			}
+
+			met := make(map[[2]uint]int)
+			for r, round := range rounds {
+				if len(round) != tt.wantPerDay {
+					t.Errorf("matchday %d has %d matches, want %d", r+1, len(round), tt.wantPerDay)
+				}
+				playing := make(map[uint]bool)
+				for _, p := range round {
+					if p.HomeTeamID == p.AwayTeamID || p.HomeTeamID == 0 || p.AwayTeamID == 0 {
+						t.Fatalf("matchday %d has invalid pairing %+v", r+1, p)
+					}
+					if playing[p.HomeTeamID] || playing[p.AwayTeamID] {
+						t.Errorf("matchday %d has a team playing twice: %+v", r+1, p)
+					}
+					playing[p.HomeTeamID] = true
+					playing[p.AwayTeamID] = true
+
+					a, b := p.HomeTeamID, p.AwayTeamID
+					if a > b {
+						a, b = b, a
+					}
+					met[[2]uint{a, b}]++
+				}
+				if resting := tt.teams - len(playing); resting != tt.wantResting {
+					t.Errorf("matchday %d has %d resting teams, want %d", r+1, resting, tt.wantResting)
+				}
+			}
+
+			if want := tt.teams * (tt.teams - 1) / 2; len(met) != want {
+				t.Errorf("got %d distinct pairings, want %d", len(met), want)
+			}
+			for pair, n := range met {
+				if n != 1 {
+					t.Errorf("teams %v meet %d times, want once", pair, n)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateRoundRobinHomeAwayBalance(t *testing.T) {
+	ids := []uint{1, 2, 3, 4, 5, 6, 7, 8}
+	rounds := generateRoundRobin(ids)
+
+	home := make(map[uint]int)
+	for _, round := range rounds {
+		for _, p := range round {
+			home[p.HomeTeamID]++
+		}
+	}
+	// Seven matches each: every team plays three or four of them at home
+	for _, id := range ids {
+		if home[id] < 3 || home[id] > 4 {
+			t.Errorf("team %d plays %d home matches, want 3 or 4", id, home[id])
+		}
+	}
+}
+
+func TestSeasonRoundRobinDoubleRound(t *testing.T) {
+	teams := []models.Team{{ID: 4}, {ID: 2}, {ID: 3}, {ID: 1}}
+
+	single := seasonRoundRobin(teams, false)
+	double := seasonRoundRobin(teams, true)
+	if len(double) != 2*len(single) {
+		t.Fatalf("got %d matchdays, want %d", len(double), 2*len(single))
+	}
+
+	for r, round := range single {
+		mirrored := double[len(single)+r]
+		if len(mirrored) != len(round) {
+			t.Fatalf("matchday %d: second half has %d matches, want %d", r+1, len(mirrored), len(round))
+		}
+		for i, p := range round {
+			if mirrored[i].HomeTeamID != p.AwayTeamID || mirrored[i].AwayTeamID != p.HomeTeamID {
+				t.Errorf("matchday %d: %+v is not mirrored by %+v", r+1, p, mirrored[i])
+			}
+		}
+	}
+}
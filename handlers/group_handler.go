@@ -0,0 +1,504 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"ayoindo/config"
+	"ayoindo/models"
+	"ayoindo/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type GroupInput struct {
+	Name    string `json:"name" binding:"required,min=1,max=50"`
+	TeamIDs []uint `json:"team_ids" binding:"required,min=2"`
+}
+
+type GroupStageInput struct {
+	QualifiersPerGroup   int    `json:"qualifiers_per_group" binding:"required,min=1"`
+	BestThirdPlaced      int    `json:"best_third_placed" binding:"min=0"`
+	KnockoutStartDate    string `json:"knockout_start_date" binding:"omitempty,datetime=2006-01-02"` // YYYY-MM-DD
+	KnockoutIntervalDays int    `json:"knockout_interval_days" binding:"required,min=1,max=60"`
+	KnockoutMatchTime    string `json:"knockout_match_time" binding:"required,datetime=15:04"` // HH:MM
+}
+
+type GroupTable struct {
+	Group models.SeasonGroup `json:"group"`
+	Table []StandingRow      `json:"table"`
+}
+
+// groupHasTeam reports whether the team was drawn into the group
+func groupHasTeam(db *gorm.DB, groupID, teamID uint) bool {
+	var count int64
+	db.Table("season_group_teams").
+		Where("season_group_id = ? AND team_id = ?", groupID, teamID).
+		Count(&count)
+	return count > 0
+}
+
+// seasonKnockoutSeeded reports whether the knockout bracket of the season has been seeded from
+// its group stage; brackets created by hand do not count
+func seasonKnockoutSeeded(db *gorm.DB, seasonID uint) bool {
+	var count int64
+	db.Model(&models.Bracket{}).Where("season_id = ? AND group_stage = ?", seasonID, true).Count(&count)
+	return count > 0
+}
+
+// isGroupKnockoutSeason reports whether the season belongs to a group_knockout competition
+func isGroupKnockoutSeason(db *gorm.DB, season models.Season) bool {
+	var competition models.Competition
+	if err := db.First(&competition, season.CompetitionID).Error; err != nil {
+		return false
+	}
+	return competition.Format == models.CompetitionFormatGroupKnockout
+}
+
+// groupKnockoutOnly is the error of group stage endpoints called on other competition formats
+const groupKnockoutOnly = "Groups are only available in group_knockout competitions"
+
+// seasonGroupTables computes the table of every group of a season from its completed group matches
+func seasonGroupTables(db *gorm.DB, season models.Season) []GroupTable {
+	var groups []models.SeasonGroup
+	db.Preload("Teams").Where("season_id = ?", season.ID).Order("name ASC").Find(&groups)
+
+	rules := seasonStandingsRules(season)
+	matches := seasonCompletedMatches(db, season.ID)
+
+	tables := make([]GroupTable, 0, len(groups))
+	for _, g := range groups {
+		var groupMatches []models.Match
+		for _, m := range matches {
+			if m.GroupID != nil && *m.GroupID == g.ID {
+				groupMatches = append(groupMatches, m)
+			}
+		}
+		tables = append(tables, GroupTable{
+			Group: g,
+			Table: computeStandings(g.Teams, groupMatches, rules),
+		})
+	}
+	return tables
+}
+
+// groupQualifier is a team through to the knockout stage and the group it qualified from
+type groupQualifier struct {
+	TeamID  uint
+	GroupID uint
+}
+
+// groupStageQualifiers returns the qualified teams in seeding order. Group winners are ranked first,
+// then runners-up and so on, followed by the best teams of the next position; teams on the same
+// position are ranked across groups by points, goal difference and goals scored. The seeds are then
+// arranged so that no first round match is played between two teams of the same group.
+func groupStageQualifiers(tables []GroupTable, season models.Season) []uint {
+	rowsAt := func(position int) []groupQualifier {
+		var rows []StandingRow
+		groupOf := make(map[uint]uint)
+		for _, t := range tables {
+			if position <= len(t.Table) {
+				rows = append(rows, t.Table[position-1])
+				groupOf[t.Table[position-1].TeamID] = t.Group.ID
+			}
+		}
+		sort.SliceStable(rows, func(i, j int) bool {
+			a, b := rows[i], rows[j]
+			if a.Points != b.Points {
+				return a.Points > b.Points
+			}
+			if a.GoalDifference != b.GoalDifference {
+				return a.GoalDifference > b.GoalDifference
+			}
+			return a.GoalsFor > b.GoalsFor
+		})
+		qualifiers := make([]groupQualifier, 0, len(rows))
+		for _, r := range rows {
+			qualifiers = append(qualifiers, groupQualifier{TeamID: r.TeamID, GroupID: groupOf[r.TeamID]})
+		}
+		return qualifiers
+	}
+
+	var ranked []groupQualifier
+	for position := 1; position <= season.QualifiersPerGroup; position++ {
+		ranked = append(ranked, rowsAt(position)...)
+	}
+
+	if season.BestThirdPlaced > 0 {
+		best := rowsAt(season.QualifiersPerGroup + 1)
+		for i := 0; i < len(best) && i < season.BestThirdPlaced; i++ {
+			ranked = append(ranked, best[i])
+		}
+	}
+	return crossGroupSeeds(ranked)
+}
+
+// crossGroupSeeds turns qualifiers ranked best first into bracket seeds. Seed s meets seed n+1-s in
+// round 1 (see bracketSeedOrder), so the top half is paired with the bottom half: the best qualifier
+// with the weakest one from another group, e.g. A1 vs B2 and B1 vs A2 for two groups. Teams of the
+// same group only meet in round 1 when no other pairing is left.
+func crossGroupSeeds(ranked []groupQualifier) []uint {
+	n := len(ranked)
+	seeds := make([]uint, n)
+	if n%2 != 0 {
+		for i, q := range ranked {
+			seeds[i] = q.TeamID
+		}
+		return seeds
+	}
+
+	top, bottom := ranked[:n/2], ranked[n/2:]
+	opponents := make([]groupQualifier, len(top))
+	used := make([]bool, len(bottom))
+	for i, team := range top {
+		pick := -1
+		for j := len(bottom) - 1; j >= 0; j-- {
+			if used[j] {
+				continue
+			}
+			if pick == -1 {
+				pick = j // the weakest one left, if every other team shares the group
+			}
+			if bottom[j].GroupID != team.GroupID {
+				pick = j
+				break
+			}
+		}
+		used[pick] = true
+		opponents[i] = bottom[pick]
+	}
+
+	// The last teams may be left with an opponent of their own group; swap with another pairing
+	for i := range top {
+		if opponents[i].GroupID != top[i].GroupID {
+			continue
+		}
+		for k := range top {
+			if k != i && opponents[k].GroupID != top[i].GroupID && opponents[i].GroupID != top[k].GroupID {
+				opponents[i], opponents[k] = opponents[k], opponents[i]
+				break
+			}
+		}
+	}
+
+	for i := range top {
+		seeds[i] = top[i].TeamID
+		seeds[n-1-i] = opponents[i].TeamID
+	}
+	return seeds
+}
+
+// seasonGroupSizes returns the number of teams in each group of the season
+func seasonGroupSizes(db *gorm.DB, seasonID uint) []int {
+	var groups []models.SeasonGroup
+	db.Preload("Teams").Where("season_id = ?", seasonID).Order("name ASC").Find(&groups)
+	sizes := make([]int, 0, len(groups))
+	for _, g := range groups {
+		sizes = append(sizes, len(g.Teams))
+	}
+	return sizes
+}
+
+// groupStageSizeError explains why the qualification rules cannot fill a knockout bracket from groups
+// of the given sizes, or returns "" when they can. The number of qualifiers must be a power of two.
+func groupStageSizeError(groupSizes []int, qualifiersPerGroup, bestPlaced int) string {
+	if len(groupSizes) == 0 {
+		return "The season has no groups"
+	}
+	smallest, larger := groupSizes[0], 0
+	for _, size := range groupSizes {
+		if size < smallest {
+			smallest = size
+		}
+		if size > qualifiersPerGroup {
+			larger++
+		}
+	}
+	if qualifiersPerGroup > smallest {
+		return fmt.Sprintf("qualifiers_per_group (%d) exceeds the size of the smallest group (%d teams)", qualifiersPerGroup, smallest)
+	}
+	if bestPlaced > larger {
+		return fmt.Sprintf("best_third_placed (%d) exceeds the number of groups with more than %d teams (%d)", bestPlaced, qualifiersPerGroup, larger)
+	}
+	total := len(groupSizes)*qualifiersPerGroup + bestPlaced
+	if total < 2 || total&(total-1) != 0 {
+		return fmt.Sprintf("%d qualifiers cannot fill a knockout bracket; the number of qualifiers must be a power of two", total)
+	}
+	return ""
+}
+
+// groupStagePending explains what is left to play in the group stage, or returns "" once no group
+// match is open and every pairing within each group has a completed match
+func groupStagePending(db *gorm.DB, seasonID uint) string {
+	var open int64
+	db.Model(&models.Match{}).
+		Where("season_id = ? AND group_id IS NOT NULL AND status IN ?", seasonID, models.OpenMatchStatuses).
+		Count(&open)
+	if open > 0 {
+		return fmt.Sprintf("%d group match(es) have not been decided yet", open)
+	}
+
+	var groups []models.SeasonGroup
+	db.Preload("Teams", func(db *gorm.DB) *gorm.DB { return db.Order("teams.id ASC") }).
+		Where("season_id = ?", seasonID).Order("name ASC").Find(&groups)
+	if len(groups) == 0 {
+		return "The season has no groups"
+	}
+	for _, g := range groups {
+		for i, home := range g.Teams {
+			for _, away := range g.Teams[i+1:] {
+				var played int64
+				completedMatchesQuery(db).
+					Where("matches.group_id = ? AND ((matches.home_team_id = ? AND matches.away_team_id = ?) OR (matches.home_team_id = ? AND matches.away_team_id = ?))",
+						g.ID, home.ID, away.ID, away.ID, home.ID).
+					Count(&played)
+				if played == 0 {
+					return fmt.Sprintf("%s: %s vs %s has no completed match", g.Name, home.Name, away.Name)
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// createGroupKnockout seeds the knockout bracket from the final group tables.
+// Callers check that the group stage is complete and the rules fill a bracket.
+func createGroupKnockout(tx *gorm.DB, season models.Season) (models.Bracket, error) {
+	qualifiers := groupStageQualifiers(seasonGroupTables(tx, season), season)
+
+	startDate := season.KnockoutStartDate
+	if startDate == "" {
+		var last models.Match
+		if err := tx.Where("season_id = ? AND group_id IS NOT NULL", season.ID).
+			Order("kickoff_at DESC").First(&last).Error; err != nil {
+			return models.Bracket{}, err
+		}
+		lastDate, err := time.Parse("2006-01-02", last.MatchDate)
+		if err != nil {
+			return models.Bracket{}, err
+		}
+		startDate = lastDate.AddDate(0, 0, season.KnockoutIntervalDays).Format("2006-01-02")
+	}
+
+	bracket := models.Bracket{
+		SeasonID:     season.ID,
+		Name:         models.KnockoutStageBracketName,
+		GroupStage:   true,
+		StartDate:    startDate,
+		IntervalDays: season.KnockoutIntervalDays,
+		MatchTime:    season.KnockoutMatchTime,
+	}
+	err := createBracket(tx, &bracket, qualifiers)
+	return bracket, err
+}
+
+// seedKnockoutFromGroups creates the knockout bracket once the group stage is complete and returns it.
+// It returns nil outside group_knockout competitions, while group matches remain, if the rules cannot
+// fill a bracket or if the knockout stage is already seeded; POST /api/seasons/:id/group-stage/seed
+// reports why.
+func seedKnockoutFromGroups(tx *gorm.DB, seasonID uint) (*models.Bracket, error) {
+	var season models.Season
+	if err := tx.First(&season, seasonID).Error; err != nil {
+		return nil, err
+	}
+	if !isGroupKnockoutSeason(tx, season) || seasonKnockoutSeeded(tx, season.ID) || groupStagePending(tx, season.ID) != "" ||
+		groupStageSizeError(seasonGroupSizes(tx, season.ID), season.QualifiersPerGroup, season.BestThirdPlaced) != "" {
+		return nil, nil
+	}
+
+	bracket, err := createGroupKnockout(tx, season)
+	if err != nil {
+		return nil, err
+	}
+	return &bracket, nil
+}
+
+// GetSeasonGroups godoc
+// GET /api/seasons/:id/groups — groups with their current tables
+func GetSeasonGroups(c *gin.Context) {
+	id := c.Param("id")
+	var season models.Season
+	if err := config.DB.First(&season, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Season not found")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Groups retrieved successfully", seasonGroupTables(config.DB, season))
+}
+
+// CreateSeasonGroup godoc
+// POST /api/seasons/:id/groups
+func CreateSeasonGroup(c *gin.Context) {
+	id := c.Param("id")
+	var season models.Season
+	if err := config.DB.First(&season, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Season not found")
+		return
+	}
+	if !isGroupKnockoutSeason(config.DB, season) {
+		utils.ValidationErrorResponse(c, groupKnockoutOnly)
+		return
+	}
+
+	var input GroupInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	for _, teamID := range input.TeamIDs {
+		if !seasonHasTeam(season.ID, teamID) {
+			utils.ValidationErrorResponse(c, "All teams must be registered in the season")
+			return
+		}
+	}
+
+	// A team can only play in one group per season
+	var taken int64
+	config.DB.Table("season_group_teams").
+		Joins("JOIN season_groups ON season_groups.id = season_group_teams.season_group_id AND season_groups.deleted_at IS NULL").
+		Where("season_groups.season_id = ? AND season_group_teams.team_id IN ?", season.ID, input.TeamIDs).
+		Count(&taken)
+	if taken > 0 {
+		utils.ErrorResponse(c, http.StatusConflict, "One or more teams are already in a group of this season")
+		return
+	}
+
+	teams, ok := loadSeasonTeams(input.TeamIDs)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusNotFound, "One or more teams not found")
+		return
+	}
+
+	group := models.SeasonGroup{
+		SeasonID: season.ID,
+		Name:     input.Name,
+		Teams:    teams,
+	}
+
+	if err := config.DB.Create(&group).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create group")
+		return
+	}
+
+	config.DB.Preload("Teams").First(&group, group.ID)
+	utils.SuccessResponse(c, http.StatusCreated, "Group created successfully", group)
+}
+
+// DeleteSeasonGroup godoc
+// DELETE /api/seasons/:id/groups/:group_id — soft delete, only before fixtures exist
+func DeleteSeasonGroup(c *gin.Context) {
+	var group models.SeasonGroup
+	if err := config.DB.Where("season_id = ?", c.Param("id")).First(&group, c.Param("group_id")).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Group not found")
+		return
+	}
+
+	var matches int64
+	config.DB.Model(&models.Match{}).Where("group_id = ?", group.ID).Count(&matches)
+	if matches > 0 {
+		utils.ErrorResponse(c, http.StatusConflict, "Cannot delete a group that already has matches")
+		return
+	}
+
+	if err := config.DB.Delete(&group).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete group")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Group deleted successfully", nil)
+}
+
+// UpdateSeasonGroupStage godoc
+// PUT /api/seasons/:id/group-stage — qualification rules and knockout schedule
+func UpdateSeasonGroupStage(c *gin.Context) {
+	id := c.Param("id")
+	var season models.Season
+	if err := config.DB.First(&season, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Season not found")
+		return
+	}
+	if !isGroupKnockoutSeason(config.DB, season) {
+		utils.ValidationErrorResponse(c, groupKnockoutOnly)
+		return
+	}
+
+	if seasonKnockoutSeeded(config.DB, season.ID) {
+		utils.ErrorResponse(c, http.StatusConflict, "The knockout stage has already been seeded")
+		return
+	}
+
+	var input GroupStageInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	// Groups may still be drawn later; the rules are checked again when the bracket is seeded
+	if sizes := seasonGroupSizes(config.DB, season.ID); len(sizes) > 0 {
+		if msg := groupStageSizeError(sizes, input.QualifiersPerGroup, input.BestThirdPlaced); msg != "" {
+			utils.ValidationErrorResponse(c, msg)
+			return
+		}
+	}
+
+	season.QualifiersPerGroup = input.QualifiersPerGroup
+	season.BestThirdPlaced = input.BestThirdPlaced
+	season.KnockoutStartDate = input.KnockoutStartDate
+	season.KnockoutIntervalDays = input.KnockoutIntervalDays
+	season.KnockoutMatchTime = input.KnockoutMatchTime
+
+	if err := config.DB.Save(&season).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update group stage")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Group stage updated successfully", season)
+}
+
+// SeedSeasonKnockout godoc
+// POST /api/seasons/:id/group-stage/seed — seeds the knockout stage when it was not seeded by the last result
+func SeedSeasonKnockout(c *gin.Context) {
+	id := c.Param("id")
+	var season models.Season
+	if err := config.DB.First(&season, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Season not found")
+		return
+	}
+	if !isGroupKnockoutSeason(config.DB, season) {
+		utils.ValidationErrorResponse(c, groupKnockoutOnly)
+		return
+	}
+
+	if seasonKnockoutSeeded(config.DB, season.ID) {
+		utils.ErrorResponse(c, http.StatusConflict, "The knockout stage has already been seeded")
+		return
+	}
+	if msg := groupStagePending(config.DB, season.ID); msg != "" {
+		utils.ErrorResponse(c, http.StatusConflict, "The group stage is not complete: "+msg)
+		return
+	}
+	if msg := groupStageSizeError(seasonGroupSizes(config.DB, season.ID), season.QualifiersPerGroup, season.BestThirdPlaced); msg != "" {
+		utils.ValidationErrorResponse(c, msg)
+		return
+	}
+
+	tx := config.DB.Begin()
+	bracket, err := createGroupKnockout(tx, season)
+	if err != nil {
+		tx.Rollback()
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to seed the knockout stage")
+		return
+	}
+	if !rejectScheduleConflicts(c, tx, bracketMatches(tx, bracket.ID)) {
+		tx.Rollback()
+		return
+	}
+	tx.Commit()
+
+	tree, _ := loadBracketTree(bracket.ID)
+	utils.SuccessResponse(c, http.StatusCreated, "Knockout stage seeded successfully", tree)
+}
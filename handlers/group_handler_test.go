@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+
+	"ayoindo/models"
+)
+
+// groupTable builds a final group table; each row is {team_id, points, goal_difference, goals_for}
+func groupTable(groupID uint, rows ...[4]int) GroupTable {
+	table := GroupTable{Group: models.SeasonGroup{ID: groupID}}
+	for i, r := range rows {
+		table.Table = append(table.Table, StandingRow{
+			Position:       i + 1,
+			TeamID:         uint(r[0]),
+			Points:         r[1],
+			GoalDifference: r[2],
+			GoalsFor:       r[3],
+		})
+	}
+	return table
+}
+
+func TestGroupStageQualifiers(t *testing.T) {
+	tests := []struct {
+		name   string
+		tables []GroupTable
+		season models.Season
+		want   []uint // seeds; seed i meets seed n-1-i in round 1
+	}{
+		{
+			name: "two groups cross over",
+			tables: []GroupTable{
+				groupTable(1, [4]int{11, 9, 5, 7}, [4]int{12, 6, 2, 5}, [4]int{13, 3, -7, 1}),
+				groupTable(2, [4]int{21, 7, 4, 6}, [4]int{22, 4, 0, 3}, [4]int{23, 0, -4, 2}),
+			},
+			season: models.Season{QualifiersPerGroup: 2},
+			want:   []uint{11, 21, 12, 22}, // A1 vs B2, B1 vs A2
+		},
+		{
+			name: "winners ranked across groups",
+			tables: []GroupTable{
+				groupTable(1, [4]int{11, 6, 1, 4}, [4]int{12, 6, 3, 5}),
+				groupTable(2, [4]int{21, 9, 2, 4}, [4]int{22, 3, -2, 2}),
+			},
+			season: models.Season{QualifiersPerGroup: 2},
+			want:   []uint{21, 11, 22, 12}, // B1 vs A2, A1 vs B2
+		},
+		{
+			name: "best third placed teams",
+			tables: []GroupTable{
+				groupTable(1, [4]int{11, 9, 6, 8}, [4]int{12, 6, 2, 5}, [4]int{13, 3, -1, 4}, [4]int{14, 0, -7, 1}),
+				groupTable(2, [4]int{21, 7, 4, 6}, [4]int{22, 5, 1, 4}, [4]int{23, 4, 0, 3}, [4]int{24, 1, -5, 2}),
+				groupTable(3, [4]int{31, 8, 5, 7}, [4]int{32, 4, 0, 3}, [4]int{33, 3, -2, 2}, [4]int{34, 2, -3, 3}),
+			},
+			season: models.Season{QualifiersPerGroup: 2, BestThirdPlaced: 2},
+			// Ranked: 11 31 21 12 22 32 23 13; the top four meet the bottom four of other groups
+			want: []uint{11, 31, 21, 12, 22, 32, 13, 23},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seeds := groupStageQualifiers(tt.tables, tt.season)
+			if len(seeds) != len(tt.want) {
+				t.Fatalf("got %d qualifiers, want %d", len(seeds), len(tt.want))
+			}
+			for i := range tt.want {
+				if seeds[i] != tt.want[i] {
+					t.Fatalf("got seeds %v, want %v", seeds, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCrossGroupSeedsAvoidsSameGroupPairings(t *testing.T) {
+	tests := []struct {
+		name   string
+		ranked []groupQualifier
+	}{
+		{
+			name: "four groups",
+			ranked: []groupQualifier{
+				{11, 1}, {21, 2}, {31, 3}, {41, 4},
+				{32, 3}, {12, 1}, {42, 4}, {22, 2},
+			},
+		},
+		{
+			name: "weakest runner-up shares the group of the best winner",
+			ranked: []groupQualifier{
+				{11, 1}, {21, 2},
+				{22, 2}, {12, 1},
+			},
+		},
+		{
+			name: "greedy pass needs a swap",
+			ranked: []groupQualifier{
+				{11, 1}, {21, 2}, {12, 1}, {31, 3},
+				{32, 3}, {22, 2}, {33, 3}, {13, 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groupOf := make(map[uint]uint)
+			for _, q := range tt.ranked {
+				groupOf[q.TeamID] = q.GroupID
+			}
+
+			seeds := crossGroupSeeds(tt.ranked)
+			n := len(seeds)
+			seen := make(map[uint]bool)
+			for _, id := range seeds {
+				if seen[id] {
+					t.Fatalf("team %d is seeded twice: %v", id, seeds)
+				}
+				seen[id] = true
+			}
+			for i := 0; i < n/2; i++ {
+				if seeds[i] != tt.ranked[i].TeamID {
+					t.Errorf("seed %d is team %d, want the ranked team %d", i+1, seeds[i], tt.ranked[i].TeamID)
+				}
+				if groupOf[seeds[i]] == groupOf[seeds[n-1-i]] {
+					t.Errorf("teams %d and %d of the same group meet in round 1: %v", seeds[i], seeds[n-1-i], seeds)
+				}
+			}
+		})
+	}
+}
+
+func TestGroupStageSizeError(t *testing.T) {
+	tests := []struct {
+		name       string
+		groupSizes []int
+		qualifiers int
+		bestPlaced int
+		wantError  string // substring, "" for valid rules
+	}{
+		{name: "two groups of four", groupSizes: []int{4, 4}, qualifiers: 2},
+		{name: "four groups of four", groupSizes: []int{4, 4, 4, 4}, qualifiers: 2},
+		{name: "three groups with best third placed", groupSizes: []int{4, 4, 4}, qualifiers: 2, bestPlaced: 2},
+		{name: "no groups", qualifiers: 2, wantError: "no groups"},
+		{name: "more qualifiers than the smallest group", groupSizes: []int{4, 3}, qualifiers: 4, wantError: "smallest group (3 teams)"},
+		{name: "more best placed than groups", groupSizes: []int{4, 4}, qualifiers: 1, bestPlaced: 3, wantError: "best_third_placed (3)"},
+		{name: "best placed from groups without a next team", groupSizes: []int{4, 3, 3}, qualifiers: 3, bestPlaced: 2, wantError: "best_third_placed (2)"},
+		{name: "not a power of two", groupSizes: []int{4, 4, 4}, qualifiers: 2, wantError: "6 qualifiers"},
+		{name: "single qualifier", groupSizes: []int{4}, qualifiers: 1, wantError: "1 qualifiers"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := groupStageSizeError(tt.groupSizes, tt.qualifiers, tt.bestPlaced)
+			if tt.wantError == "" {
+				if got != "" {
+					t.Errorf("got error %q, want none", got)
+				}
+				return
+			}
+			if !strings.Contains(got, tt.wantError) {
+				t.Errorf("got error %q, want it to mention %q", got, tt.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,309 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"ayoindo/config"
+	"ayoindo/models"
+	"ayoindo/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Size of a lineup
+const (
+	lineupStarters   = 11
+	maxLineupBench   = 12
+	maxLineupPlayers = lineupStarters + maxLineupBench
+)
+
+type LineupPlayerInput struct {
+	PlayerID     uint `json:"player_id" binding:"required"`
+	JerseyNumber int  `json:"jersey_number" binding:"omitempty,min=1,max=99"` // defaults to the player's registered number
+}
+
+type LineupInput struct {
+	TeamID    uint                `json:"team_id" binding:"required"`
+	CaptainID uint                `json:"captain_id" binding:"required"` // must be one of the starters
+	Starting  []LineupPlayerInput `json:"starting" binding:"required,dive"`
+	Bench     []LineupPlayerInput `json:"bench" binding:"dive"`
+}
+
+// orderLineupPlayers preloads starters first, then substitutes, each by jersey number
+func orderLineupPlayers(db *gorm.DB) *gorm.DB {
+	return db.Order("starter DESC, jersey_number ASC")
+}
+
+// matchLineupPlayers returns, per team, the players named in the match lineups.
+// Teams without a lineup are missing from the map.
+func matchLineupPlayers(db *gorm.DB, matchID uint) map[uint]map[uint]bool {
+	var lineups []models.MatchLineup
+	db.Preload("Players").Where("match_id = ?", matchID).Find(&lineups)
+
+	named := make(map[uint]map[uint]bool)
+	for _, l := range lineups {
+		named[l.TeamID] = make(map[uint]bool)
+		for _, p := range l.Players {
+			named[l.TeamID][p.PlayerID] = true
+		}
+	}
+	return named
+}
+
+// rejectPlayersOutsideLineup writes an error response and returns false when a player is not named
+// in their team's lineup for the match, or their team has not submitted one
+func rejectPlayersOutsideLineup(c *gin.Context, match models.Match, playerIDs []uint) bool {
+	if len(playerIDs) == 0 {
+		return true
+	}
+	named := matchLineupPlayers(config.DB, match.ID)
+	for _, id := range playerIDs {
+		player, err := loadMatchPlayer(config.DB, id, match)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusNotFound, fmt.Sprintf("Player not found: player_id %d", id))
+			return false
+		}
+		lineup, ok := named[player.TeamID]
+		if !ok {
+			utils.ValidationErrorResponse(c, fmt.Sprintf("Team %d has not submitted a lineup for this match, so player %d cannot be named", player.TeamID, player.ID))
+			return false
+		}
+		if !lineup[player.ID] {
+			utils.ValidationErrorResponse(c, fmt.Sprintf("Player %d is not in the lineup of their team", player.ID))
+			return false
+		}
+	}
+	return true
+}
+
+// removeStaleLineups deletes the lineups of teams that no longer play in the match
+func removeStaleLineups(tx *gorm.DB, match models.Match) error {
+	stale := tx.Model(&models.MatchLineup{}).Select("id").
+		Where("match_id = ? AND team_id NOT IN ?", match.ID, []uint{match.HomeTeamID, match.AwayTeamID})
+	if err := tx.Where("lineup_id IN (?)", stale).Delete(&models.LineupPlayer{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("match_id = ? AND team_id NOT IN ?", match.ID, []uint{match.HomeTeamID, match.AwayTeamID}).
+		Delete(&models.MatchLineup{}).Error
+}
+
+// GetMatchLineups godoc
+// GET /api/matches/:id/lineups
+func GetMatchLineups(c *gin.Context) {
+	id := c.Param("id")
+	var match models.Match
+	if err := config.DB.First(&match, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Match not found")
+		return
+	}
+
+	var lineups []models.MatchLineup
+	config.DB.
+		Preload("Team").
+		Preload("Captain").
+		Preload("Players", orderLineupPlayers).
+		Preload("Players.Player").
+		Where("match_id = ?", match.ID).
+		Order("id ASC").
+		Find(&lineups)
+
+	utils.SuccessResponse(c, http.StatusOK, "Lineups retrieved successfully", lineups)
+}
+
+// SubmitMatchLineup godoc
+// POST /api/matches/:id/lineups — creates or replaces the lineup of one team until it is locked
+func SubmitMatchLineup(c *gin.Context) {
+	id := c.Param("id")
+	var match models.Match
+	if err := config.DB.First(&match, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Match not found")
+		return
+	}
+
+	if match.Status.IsDecided() || match.Status == models.MatchStatusCancelled {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Cannot submit a lineup for a "+string(match.Status)+" match")
+		return
+	}
+
+	var input LineupInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	if input.TeamID != match.HomeTeamID && input.TeamID != match.AwayTeamID {
+		utils.ValidationErrorResponse(c, "Team does not play in this match")
+		return
+	}
+	if len(input.Starting) != lineupStarters {
+		utils.ValidationErrorResponse(c, fmt.Sprintf("A lineup must have exactly %d starters", lineupStarters))
+		return
+	}
+	if len(input.Bench) > maxLineupBench {
+		utils.ValidationErrorResponse(c, fmt.Sprintf("A lineup can have at most %d substitutes", maxLineupBench))
+		return
+	}
+
+	var existing models.MatchLineup
+	lineupExists := config.DB.Where("match_id = ? AND team_id = ?", match.ID, input.TeamID).First(&existing).Error == nil
+	if lineupExists && existing.Locked {
+		utils.ErrorResponse(c, http.StatusConflict, "The lineup is locked and can no longer be changed")
+		return
+	}
+
+	// Validate the squad: players of the team, each named once, unique jerseys, one starting goalkeeper
+	players := make([]models.LineupPlayer, 0, maxLineupPlayers)
+	seenPlayers := make(map[uint]bool)
+	seenJerseys := make(map[int]bool)
+	playerIDs := make([]uint, 0, maxLineupPlayers)
+	goalkeepers := 0
+	captainStarts := false
+
+	add := func(in LineupPlayerInput, starter bool) bool {
+		player, err := loadMatchPlayer(config.DB, in.PlayerID, match)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusNotFound, fmt.Sprintf("Player not found: player_id %d", in.PlayerID))
+			return false
+		}
+		if player.TeamID != input.TeamID {
+			utils.ValidationErrorResponse(c, fmt.Sprintf("Player %d does not belong to this team", player.ID))
+			return false
+		}
+		if seenPlayers[player.ID] {
+			utils.ValidationErrorResponse(c, fmt.Sprintf("Player %d is named more than once", player.ID))
+			return false
+		}
+		seenPlayers[player.ID] = true
+
+		jersey := in.JerseyNumber
+		if jersey == 0 {
+			jersey = player.JerseyNumber
+		}
+		if seenJerseys[jersey] {
+			utils.ValidationErrorResponse(c, fmt.Sprintf("Jersey number %d is used more than once", jersey))
+			return false
+		}
+		seenJerseys[jersey] = true
+
+		if starter && player.Position == models.PositionPenjagaGawang {
+			goalkeepers++
+		}
+		if starter && player.ID == input.CaptainID {
+			captainStarts = true
+		}
+
+		playerIDs = append(playerIDs, player.ID)
+		players = append(players, models.LineupPlayer{
+			PlayerID:     player.ID,
+			JerseyNumber: jersey,
+			Position:     player.Position,
+			Starter:      starter,
+		})
+		return true
+	}
+
+	for _, in := range input.Starting {
+		if !add(in, true) {
+			return
+		}
+	}
+	for _, in := range input.Bench {
+		if !add(in, false) {
+			return
+		}
+	}
+
+	if goalkeepers != 1 {
+		utils.ValidationErrorResponse(c, "The starting lineup must have exactly one penjaga_gawang")
+		return
+	}
+	if !captainStarts {
+		utils.ValidationErrorResponse(c, "The captain must be one of the starters")
+		return
+	}
+
+	if !rejectSuspendedPlayers(c, match, playerIDs) {
+		return
+	}
+
+	tx := config.DB.Begin()
+
+	lineup := existing
+	if lineupExists {
+		if err := tx.Where("lineup_id = ?", lineup.ID).Delete(&models.LineupPlayer{}).Error; err != nil {
+			tx.Rollback()
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update lineup")
+			return
+		}
+		lineup.CaptainID = input.CaptainID
+		if err := tx.Save(&lineup).Error; err != nil {
+			tx.Rollback()
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update lineup")
+			return
+		}
+	} else {
+		lineup = models.MatchLineup{
+			MatchID:   match.ID,
+			TeamID:    input.TeamID,
+			CaptainID: input.CaptainID,
+		}
+		if err := tx.Create(&lineup).Error; err != nil {
+			tx.Rollback()
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create lineup")
+			return
+		}
+	}
+
+	for i := range players {
+		players[i].LineupID = lineup.ID
+	}
+	if err := tx.Create(&players).Error; err != nil {
+		tx.Rollback()
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to save lineup players")
+		return
+	}
+
+	tx.Commit()
+
+	config.DB.
+		Preload("Team").
+		Preload("Captain").
+		Preload("Players", orderLineupPlayers).
+		Preload("Players.Player").
+		First(&lineup, lineup.ID)
+	utils.SuccessResponse(c, http.StatusOK, "Lineup submitted successfully", lineup)
+}
+
+// LockMatchLineup godoc
+// POST /api/matches/:id/lineups/:team_id/lock
+func LockMatchLineup(c *gin.Context) {
+	var lineup models.MatchLineup
+	if err := config.DB.Where("match_id = ? AND team_id = ?", c.Param("id"), c.Param("team_id")).First(&lineup).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Lineup not found")
+		return
+	}
+
+	if lineup.Locked {
+		utils.ErrorResponse(c, http.StatusConflict, "The lineup is already locked")
+		return
+	}
+
+	now := time.Now()
+	lineup.Locked = true
+	lineup.LockedAt = &now
+	if err := config.DB.Save(&lineup).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to lock lineup")
+		return
+	}
+
+	config.DB.
+		Preload("Team").
+		Preload("Captain").
+		Preload("Players", orderLineupPlayers).
+		Preload("Players.Player").
+		First(&lineup, lineup.ID)
+	utils.SuccessResponse(c, http.StatusOK, "Lineup locked successfully", lineup)
+}
@@ -2,34 +2,167 @@ package handlers
 
 import (
 	"net/http"
+	"time"
 
 	"ayoindo/config"
 	"ayoindo/models"
 	"ayoindo/utils"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type MatchInput struct {
-	HomeTeamID uint   `json:"home_team_id" binding:"required"`
-	AwayTeamID uint   `json:"away_team_id" binding:"required"`
-	MatchDate  string `json:"match_date" binding:"required"` // YYYY-MM-DD
-	MatchTime  string `json:"match_time" binding:"required"` // HH:MM
+	HomeTeamID uint       `json:"home_team_id" binding:"required"`
+	AwayTeamID uint       `json:"away_team_id" binding:"required"`
+	MatchDate  string     `json:"match_date" binding:"required_without=KickoffAt"` // YYYY-MM-DD, local to the venue or competition
+	MatchTime  string     `json:"match_time" binding:"required_without=KickoffAt"` // HH:MM, local to the venue or competition
+	KickoffAt  *time.Time `json:"kickoff_at"`                                      // RFC 3339, replaces match_date and match_time
+	SeasonID   *uint      `json:"season_id"`
+	GroupID    *uint      `json:"group_id"` // requires season_id
+	VenueID    *uint      `json:"venue_id"`
+}
+
+// validateMatchSeason checks that the season (and group) exists and both teams take part in it
+func validateMatchSeason(c *gin.Context, input MatchInput) bool {
+	if input.SeasonID == nil {
+		if input.GroupID != nil {
+			utils.ValidationErrorResponse(c, "A group match needs a season_id")
+			return false
+		}
+		return true
+	}
+
+	var season models.Season
+	if err := config.DB.First(&season, *input.SeasonID).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Season not found")
+		return false
+	}
+	if !seasonHasTeam(season.ID, input.HomeTeamID) || !seasonHasTeam(season.ID, input.AwayTeamID) {
+		utils.ValidationErrorResponse(c, "Both teams must be registered in the season")
+		return false
+	}
+
+	if input.GroupID != nil {
+		var group models.SeasonGroup
+		if err := config.DB.Where("season_id = ?", season.ID).First(&group, *input.GroupID).Error; err != nil {
+			utils.ErrorResponse(c, http.StatusNotFound, "Group not found in this season")
+			return false
+		}
+		if !groupHasTeam(config.DB, group.ID, input.HomeTeamID) || !groupHasTeam(config.DB, group.ID, input.AwayTeamID) {
+			utils.ValidationErrorResponse(c, "Both teams must be in the group")
+			return false
+		}
+	}
+	return true
+}
+
+// kickoffLocation returns the time zone a match is played in: that of its venue,
+// else that of its competition, else the league's default
+func kickoffLocation(db *gorm.DB, venueID, seasonID *uint) *time.Location {
+	name := ""
+	if venueID != nil {
+		var venue models.Venue
+		if err := db.First(&venue, *venueID).Error; err == nil {
+			name = venue.Timezone
+		}
+	}
+	if name == "" && seasonID != nil {
+		var season models.Season
+		if err := db.Preload("Competition").First(&season, *seasonID).Error; err == nil && season.Competition != nil {
+			name = season.Competition.Timezone
+		}
+	}
+	loc, err := config.LoadLocation(name)
+	if err != nil {
+		return config.Location()
+	}
+	return loc
+}
+
+// parseLocalKickoff reads a YYYY-MM-DD date and HH:MM time in the given time zone
+func parseLocalKickoff(date, clock string, loc *time.Location) (time.Time, error) {
+	return time.ParseInLocation("2006-01-02 15:04", date+" "+clock, loc)
+}
+
+// setKickoff schedules a match at the given instant and keeps its local date and time in step
+func setKickoff(match *models.Match, kickoff time.Time, loc *time.Location) {
+	local := kickoff.In(loc)
+	match.KickoffAt = &local
+	match.MatchDate = local.Format("2006-01-02")
+	match.MatchTime = local.Format("15:04")
+	match.Timezone = loc.String()
+}
+
+// applyMatchKickoff sets the kickoff from the input, reading a local date and time in the time zone
+// of the match's venue or competition. The venue and season of the match must already be set.
+func applyMatchKickoff(c *gin.Context, match *models.Match, input MatchInput) bool {
+	loc := kickoffLocation(config.DB, match.VenueID, match.SeasonID)
+	if input.KickoffAt != nil {
+		setKickoff(match, *input.KickoffAt, loc)
+		return true
+	}
+
+	kickoff, err := parseLocalKickoff(input.MatchDate, input.MatchTime, loc)
+	if err != nil {
+		utils.ValidationErrorResponse(c, "Invalid match_date or match_time, expected YYYY-MM-DD and HH:MM")
+		return false
+	}
+	setKickoff(match, kickoff, loc)
+	return true
+}
+
+// sameID reports whether two optional IDs are equal
+func sameID(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// matchKickoff returns the kickoff of a match; matches without a timestamp are read from their local date and time
+func matchKickoff(match models.Match) (time.Time, error) {
+	if match.KickoffAt != nil {
+		return *match.KickoffAt, nil
+	}
+	loc, err := config.LoadLocation(match.Timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return parseLocalKickoff(match.MatchDate, match.MatchTime, loc)
+}
+
+// matchesOverlap reports whether two matches are played within one match duration of each other
+func matchesOverlap(a, b models.Match) bool {
+	startA, errA := matchKickoff(a)
+	startB, errB := matchKickoff(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	duration := config.MatchDuration()
+	return startA.Before(startB.Add(duration)) && startB.Before(startA.Add(duration))
 }
 
 // GetAllMatches godoc
 // GET /api/matches
 func GetAllMatches(c *gin.Context) {
 	var matches []models.Match
-	query := config.DB.Preload("HomeTeam").Preload("AwayTeam").Preload("MatchResult").Preload("MatchResult.Goals").Preload("MatchResult.Goals.Player")
+	query := config.DB.Model(&models.Match{})
 
 	if status := c.Query("status"); status != "" {
 		query = query.Where("status = ?", status)
 	}
+	if seasonID := c.Query("season_id"); seasonID != "" {
+		query = query.Where("season_id = ?", seasonID)
+	}
+	if venueID := c.Query("venue_id"); venueID != "" {
+		query = query.Where("venue_id = ?", venueID)
+	}
 
 	var total int64
-	config.DB.Model(&models.Match{}).Count(&total)
-	query.Order("match_date ASC, match_time ASC").Find(&matches)
+	query.Count(&total)
+	query.Preload("HomeTeam").Preload("AwayTeam").Preload("Venue").Preload("MatchResult").Preload("MatchResult.Goals").Preload("MatchResult.Goals.Player").
+		Order("kickoff_at ASC, id ASC").Find(&matches)
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
@@ -48,6 +181,7 @@ func GetMatchByID(c *gin.Context) {
 	if err := config.DB.
 		Preload("HomeTeam").
 		Preload("AwayTeam").
+		Preload("Venue").
 		Preload("MatchResult").
 		Preload("MatchResult.Goals").
 		Preload("MatchResult.Goals.Player").
@@ -84,20 +218,35 @@ func CreateMatch(c *gin.Context) {
 		return
 	}
 
+	if !validateMatchSeason(c, input) {
+		return
+	}
+
 	match := models.Match{
 		HomeTeamID: input.HomeTeamID,
 		AwayTeamID: input.AwayTeamID,
-		MatchDate:  input.MatchDate,
-		MatchTime:  input.MatchTime,
+		SeasonID:   input.SeasonID,
+		GroupID:    input.GroupID,
+		VenueID:    input.VenueID,
 		Status:     models.MatchStatusScheduled,
 	}
 
+	if !applyMatchKickoff(c, &match, input) {
+		return
+	}
+	if !validateMatchVenue(c, match) {
+		return
+	}
+	if !rejectScheduleConflicts(c, config.DB, []models.Match{match}) {
+		return
+	}
+
 	if err := config.DB.Create(&match).Error; err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create match")
 		return
 	}
 
-	config.DB.Preload("HomeTeam").Preload("AwayTeam").First(&match, match.ID)
+	config.DB.Preload("HomeTeam").Preload("AwayTeam").Preload("Venue").First(&match, match.ID)
 	utils.SuccessResponse(c, http.StatusCreated, "Match created successfully", match)
 }
 
@@ -112,8 +261,9 @@ func UpdateMatch(c *gin.Context) {
 		return
 	}
 
-	if match.Status == models.MatchStatusCompleted {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Cannot update a completed match schedule")
+	// Only matches that have not kicked off can be rescheduled
+	if match.Status != models.MatchStatusScheduled && match.Status != models.MatchStatusPostponed {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Cannot update the schedule of a "+string(match.Status)+" match")
 		return
 	}
 
@@ -128,6 +278,29 @@ func UpdateMatch(c *gin.Context) {
 		return
 	}
 
+	// Omitted season, group and venue keep their current value; the group only carries over within its season
+	if input.SeasonID == nil {
+		input.SeasonID = match.SeasonID
+	}
+	if input.GroupID == nil && sameID(input.SeasonID, match.SeasonID) {
+		input.GroupID = match.GroupID
+	}
+	if input.VenueID == nil {
+		input.VenueID = match.VenueID
+	}
+
+	// Knockout pairings are filled in by the bracket, only the schedule may change
+	if isKnockoutMatch(match.ID) {
+		if input.HomeTeamID != match.HomeTeamID || input.AwayTeamID != match.AwayTeamID {
+			utils.ValidationErrorResponse(c, "Teams of a knockout match are set by its bracket")
+			return
+		}
+		if !sameID(input.SeasonID, match.SeasonID) || !sameID(input.GroupID, match.GroupID) {
+			utils.ValidationErrorResponse(c, "The season of a knockout match is set by its bracket")
+			return
+		}
+	}
+
 	var homeTeam, awayTeam models.Team
 	if err := config.DB.First(&homeTeam, input.HomeTeamID).Error; err != nil {
 		utils.ErrorResponse(c, http.StatusNotFound, "Home team not found")
@@ -138,17 +311,69 @@ func UpdateMatch(c *gin.Context) {
 		return
 	}
 
+	if !validateMatchSeason(c, input) {
+		return
+	}
+
+	previous := match
+
 	match.HomeTeamID = input.HomeTeamID
 	match.AwayTeamID = input.AwayTeamID
-	match.MatchDate = input.MatchDate
-	match.MatchTime = input.MatchTime
+	match.SeasonID = input.SeasonID
+	match.GroupID = input.GroupID
+	match.VenueID = input.VenueID
+	match.Venue = nil
 
-	if err := config.DB.Save(&match).Error; err != nil {
+	if !applyMatchKickoff(c, &match, input) {
+		return
+	}
+	rescheduled := previous.KickoffAt == nil || !previous.KickoffAt.Equal(*match.KickoffAt)
+	teamsChanged := match.HomeTeamID != previous.HomeTeamID || match.AwayTeamID != previous.AwayTeamID
+
+	if !validateMatchVenue(c, match) {
+		return
+	}
+	if !rejectScheduleConflicts(c, config.DB, []models.Match{match}) {
+		return
+	}
+	if (rescheduled || teamsChanged) && !validateMatchOfficials(c, match) {
+		return
+	}
+
+	// Calendar clients replace their copy of the event when the sequence goes up
+	if rescheduled || teamsChanged || !sameID(match.VenueID, previous.VenueID) || !sameID(match.SeasonID, previous.SeasonID) {
+		match.Sequence++
+	}
+
+	tx := config.DB.Begin()
+
+	if err := tx.Save(&match).Error; err != nil {
+		tx.Rollback()
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update match")
 		return
 	}
 
-	config.DB.Preload("HomeTeam").Preload("AwayTeam").First(&match, match.ID)
+	// Lineups of a team that no longer plays in the match are dropped
+	if teamsChanged {
+		if err := removeStaleLineups(tx, match); err != nil {
+			tx.Rollback()
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update match lineups")
+			return
+		}
+	}
+
+	// A new date for a postponed match puts it back on the schedule
+	if match.Status == models.MatchStatusPostponed && rescheduled {
+		if err := changeMatchStatus(tx, &match, models.MatchStatusScheduled, "Rescheduled to "+match.MatchDate+" "+match.MatchTime, currentUserID(c)); err != nil {
+			tx.Rollback()
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update match status")
+			return
+		}
+	}
+
+	tx.Commit()
+
+	config.DB.Preload("HomeTeam").Preload("AwayTeam").Preload("Venue").First(&match, match.ID)
 	utils.SuccessResponse(c, http.StatusOK, "Match updated successfully", match)
 }
 
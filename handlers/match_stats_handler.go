@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"ayoindo/config"
+	"ayoindo/models"
+	"ayoindo/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TeamStatsInput struct {
+	TeamID        uint `json:"team_id" binding:"required"`
+	Shots         int  `json:"shots" binding:"min=0"`
+	ShotsOnTarget int  `json:"shots_on_target" binding:"min=0"`
+	Corners       int  `json:"corners" binding:"min=0"`
+	Fouls         int  `json:"fouls" binding:"min=0"`
+	Offsides      int  `json:"offsides" binding:"min=0"`
+	Possession    *int `json:"possession" binding:"omitempty,min=0,max=100"` // percentage
+	Saves         int  `json:"saves" binding:"min=0"`
+}
+
+type PlayerStatsInput struct {
+	PlayerID      uint `json:"player_id" binding:"required"`
+	Shots         int  `json:"shots" binding:"min=0"`
+	ShotsOnTarget int  `json:"shots_on_target" binding:"min=0"`
+	Passes        int  `json:"passes" binding:"min=0"`
+	Tackles       int  `json:"tackles" binding:"min=0"`
+	Fouls         int  `json:"fouls" binding:"min=0"`
+	Saves         int  `json:"saves" binding:"min=0"`
+}
+
+type TeamStatsSheetInput struct {
+	Teams []TeamStatsInput `json:"teams" binding:"required,min=1,max=2,dive"`
+}
+
+type PlayerStatsSheetInput struct {
+	Players []PlayerStatsInput `json:"players" binding:"required,min=1,dive"`
+}
+
+type MatchStatsData struct {
+	Teams   []models.TeamMatchStats   `json:"teams"`
+	Players []models.PlayerMatchStats `json:"players"`
+}
+
+// loadMatchStats returns the team and player stat sheets of a match
+func loadMatchStats(matchID uint) MatchStatsData {
+	stats := MatchStatsData{
+		Teams:   []models.TeamMatchStats{},
+		Players: []models.PlayerMatchStats{},
+	}
+	config.DB.Preload("Team").Where("match_id = ?", matchID).Order("id ASC").Find(&stats.Teams)
+	config.DB.Preload("Player").Where("match_id = ?", matchID).Order("team_id ASC, id ASC").Find(&stats.Players)
+	return stats
+}
+
+// loadStatsMatch loads the match of a stats request; statistics exist once a match has kicked off
+func loadStatsMatch(c *gin.Context) (models.Match, bool) {
+	var match models.Match
+	if err := config.DB.First(&match, c.Param("id")).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Match not found")
+		return match, false
+	}
+
+	switch match.Status {
+	case models.MatchStatusInProgress, models.MatchStatusCompleted, models.MatchStatusAbandoned:
+		return match, true
+	}
+	utils.ErrorResponse(c, http.StatusBadRequest, "Statistics cannot be recorded for a "+string(match.Status)+" match")
+	return match, false
+}
+
+// GetMatchStats godoc
+// GET /api/matches/:id/stats
+func GetMatchStats(c *gin.Context) {
+	var match models.Match
+	if err := config.DB.First(&match, c.Param("id")).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Match not found")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Match statistics retrieved successfully", loadMatchStats(match.ID))
+}
+
+// SubmitTeamMatchStats godoc
+// POST /api/matches/:id/stats/teams — replaces the stat sheets of the submitted teams
+func SubmitTeamMatchStats(c *gin.Context) {
+	match, ok := loadStatsMatch(c)
+	if !ok {
+		return
+	}
+
+	var input TeamStatsSheetInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	seen := make(map[uint]bool)
+	possession, withPossession := 0, 0
+	rows := make([]models.TeamMatchStats, 0, len(input.Teams))
+	teamIDs := make([]uint, 0, len(input.Teams))
+	for _, t := range input.Teams {
+		if t.TeamID != match.HomeTeamID && t.TeamID != match.AwayTeamID {
+			utils.ValidationErrorResponse(c, "Team does not play in this match")
+			return
+		}
+		if seen[t.TeamID] {
+			utils.ValidationErrorResponse(c, "Each team can only be submitted once")
+			return
+		}
+		seen[t.TeamID] = true
+		if t.ShotsOnTarget > t.Shots {
+			utils.ValidationErrorResponse(c, "Shots on target cannot exceed shots")
+			return
+		}
+		if t.Possession != nil {
+			possession += *t.Possession
+			withPossession++
+		}
+
+		teamIDs = append(teamIDs, t.TeamID)
+		rows = append(rows, models.TeamMatchStats{
+			MatchID:       match.ID,
+			TeamID:        t.TeamID,
+			Shots:         t.Shots,
+			ShotsOnTarget: t.ShotsOnTarget,
+			Corners:       t.Corners,
+			Fouls:         t.Fouls,
+			Offsides:      t.Offsides,
+			Possession:    t.Possession,
+			Saves:         t.Saves,
+		})
+	}
+
+	if withPossession == 2 && possession != 100 {
+		utils.ValidationErrorResponse(c, "Possession of both teams must add up to 100")
+		return
+	}
+
+	tx := config.DB.Begin()
+
+	if err := tx.Where("match_id = ? AND team_id IN ?", match.ID, teamIDs).Delete(&models.TeamMatchStats{}).Error; err != nil {
+		tx.Rollback()
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to replace team statistics")
+		return
+	}
+	if err := tx.Create(&rows).Error; err != nil {
+		tx.Rollback()
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to save team statistics")
+		return
+	}
+
+	tx.Commit()
+
+	utils.SuccessResponse(c, http.StatusOK, "Team statistics submitted successfully", loadMatchStats(match.ID))
+}
+
+// SubmitPlayerMatchStats godoc
+// POST /api/matches/:id/stats/players — replaces the stat sheets of the submitted players
+func SubmitPlayerMatchStats(c *gin.Context) {
+	match, ok := loadStatsMatch(c)
+	if !ok {
+		return
+	}
+
+	var input PlayerStatsSheetInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	seen := make(map[uint]bool)
+	rows := make([]models.PlayerMatchStats, 0, len(input.Players))
+	playerIDs := make([]uint, 0, len(input.Players))
+	for _, p := range input.Players {
+		player, err := loadMatchPlayer(config.DB, p.PlayerID, match)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusNotFound, fmt.Sprintf("Player not found: player_id %d", p.PlayerID))
+			return
+		}
+		if player.TeamID != match.HomeTeamID && player.TeamID != match.AwayTeamID {
+			utils.ValidationErrorResponse(c, "Player does not belong to either team in this match")
+			return
+		}
+		if seen[player.ID] {
+			utils.ValidationErrorResponse(c, "Each player can only be submitted once")
+			return
+		}
+		seen[player.ID] = true
+		if p.ShotsOnTarget > p.Shots {
+			utils.ValidationErrorResponse(c, "Shots on target cannot exceed shots")
+			return
+		}
+
+		playerIDs = append(playerIDs, player.ID)
+		rows = append(rows, models.PlayerMatchStats{
+			MatchID:       match.ID,
+			PlayerID:      player.ID,
+			TeamID:        player.TeamID,
+			Shots:         p.Shots,
+			ShotsOnTarget: p.ShotsOnTarget,
+			Passes:        p.Passes,
+			Tackles:       p.Tackles,
+			Fouls:         p.Fouls,
+			Saves:         p.Saves,
+		})
+	}
+
+	// Only players named in their team's lineup can have a stat sheet
+	if !rejectPlayersOutsideLineup(c, match, playerIDs) {
+		return
+	}
+
+	tx := config.DB.Begin()
+
+	if err := tx.Where("match_id = ? AND player_id IN ?", match.ID, playerIDs).Delete(&models.PlayerMatchStats{}).Error; err != nil {
+		tx.Rollback()
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to replace player statistics")
+		return
+	}
+	if err := tx.Create(&rows).Error; err != nil {
+		tx.Rollback()
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to save player statistics")
+		return
+	}
+
+	tx.Commit()
+
+	utils.SuccessResponse(c, http.StatusOK, "Player statistics submitted successfully", loadMatchStats(match.ID))
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"net/http"
+
+	"ayoindo/config"
+	"ayoindo/models"
+	"ayoindo/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type MatchStatusInput struct {
+	Status models.MatchStatus `json:"status" binding:"required"`
+	Reason string             `json:"reason" binding:"required,min=3,max=500"`
+}
+
+// currentUserID returns the ID of the authenticated user set by AuthMiddleware
+func currentUserID(c *gin.Context) uint {
+	if id, ok := c.Get("user_id"); ok {
+		if userID, ok := id.(uint); ok {
+			return userID
+		}
+	}
+	return 0
+}
+
+// changeMatchStatus moves the match to a new status and records who did it and why.
+// Callers check that the transition is allowed.
+func changeMatchStatus(tx *gorm.DB, match *models.Match, to models.MatchStatus, reason string, userID uint) error {
+	change := models.MatchStatusChange{
+		MatchID:     match.ID,
+		FromStatus:  match.Status,
+		ToStatus:    to,
+		Reason:      reason,
+		ChangedByID: userID,
+	}
+	if err := tx.Create(&change).Error; err != nil {
+		return err
+	}
+
+	// The calendar event shows the status, and the score once the match is decided
+	if calendarEventStatus(change.FromStatus) != calendarEventStatus(to) || to.IsDecided() {
+		match.Sequence++
+	}
+	match.Status = to
+	return tx.Model(match).Updates(map[string]interface{}{"status": to, "sequence": match.Sequence}).Error
+}
+
+// TransitionMatchStatus godoc
+// POST /api/matches/:id/status
+func TransitionMatchStatus(c *gin.Context) {
+	id := c.Param("id")
+	var match models.Match
+
+	if err := config.DB.First(&match, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Match not found")
+		return
+	}
+
+	var input MatchStatusInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	if !input.Status.IsValid() {
+		utils.ValidationErrorResponse(c, "Invalid status. Must be one of: scheduled, in_progress, completed, forfeited, postponed, cancelled, abandoned")
+		return
+	}
+
+	// A match is completed or forfeited by its result, never by a bare status change
+	if input.Status.IsDecided() {
+		utils.ValidationErrorResponse(c, "Submit the match result to complete or forfeit a match")
+		return
+	}
+
+	if !match.Status.CanTransitionTo(input.Status) {
+		utils.ErrorResponse(c, http.StatusBadRequest,
+			"Illegal status transition from "+string(match.Status)+" to "+string(input.Status))
+		return
+	}
+
+	// A postponed match put back on its old kickoff must still fit the schedule around it
+	if match.Status == models.MatchStatusPostponed && input.Status == models.MatchStatusScheduled {
+		if !validateMatchVenue(c, match) || !rejectScheduleConflicts(c, config.DB, []models.Match{match}) ||
+			!validateMatchOfficials(c, match) {
+			return
+		}
+	}
+
+	tx := config.DB.Begin()
+	if err := changeMatchStatus(tx, &match, input.Status, input.Reason, currentUserID(c)); err != nil {
+		tx.Rollback()
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update match status")
+		return
+	}
+	tx.Commit()
+
+	config.DB.Preload("HomeTeam").Preload("AwayTeam").First(&match, match.ID)
+	utils.SuccessResponse(c, http.StatusOK, "Match status updated successfully", match)
+}
+
+// GetMatchStatusHistory godoc
+// GET /api/matches/:id/status-history
+func GetMatchStatusHistory(c *gin.Context) {
+	id := c.Param("id")
+	var match models.Match
+
+	if err := config.DB.First(&match, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Match not found")
+		return
+	}
+
+	var changes []models.MatchStatusChange
+	config.DB.Preload("ChangedBy").
+		Where("match_id = ?", match.ID).
+		Order("created_at ASC, id ASC").
+		Find(&changes)
+
+	utils.SuccessResponse(c, http.StatusOK, "Match status history retrieved successfully", changes)
+}
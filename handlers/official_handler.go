@@ -0,0 +1,392 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"ayoindo/config"
+	"ayoindo/models"
+	"ayoindo/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type OfficialInput struct {
+	Name             string `json:"name" binding:"required,min=2,max=100"`
+	Phone            string `json:"phone" binding:"max=30"`
+	City             string `json:"city" binding:"max=100"`
+	LicenseLevel     string `json:"license_level" binding:"max=20"`
+	AffiliatedTeamID *uint  `json:"affiliated_team_id"`
+}
+
+type MatchOfficialInput struct {
+	OfficialID uint                `json:"official_id" binding:"required"`
+	Role       models.OfficialRole `json:"role" binding:"required"`
+}
+
+type OfficialStats struct {
+	OfficialID      uint                        `json:"official_id"`
+	Matches         int                         `json:"matches"` // completed matches in any role
+	MatchesByRole   map[models.OfficialRole]int `json:"matches_by_role"`
+	UpcomingMatches int                         `json:"upcoming_matches"`
+	YellowCards     int                         `json:"yellow_cards"` // cards shown as referee
+	SecondYellows   int                         `json:"second_yellows"`
+	RedCards        int                         `json:"red_cards"`
+}
+
+// validateOfficialAffiliation checks that the affiliated team exists
+func validateOfficialAffiliation(c *gin.Context, input OfficialInput) bool {
+	if input.AffiliatedTeamID == nil {
+		return true
+	}
+	var team models.Team
+	if err := config.DB.First(&team, *input.AffiliatedTeamID).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Affiliated team not found")
+		return false
+	}
+	return true
+}
+
+// GetAllOfficials godoc
+// GET /api/officials
+func GetAllOfficials(c *gin.Context) {
+	var officials []models.Official
+	query := config.DB.Model(&models.Official{})
+
+	if city := c.Query("city"); city != "" {
+		query = query.Where("city = ?", city)
+	}
+
+	var total int64
+	query.Count(&total)
+	query.Preload("AffiliatedTeam").Order("name ASC").Find(&officials)
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Officials retrieved successfully",
+		"data":    officials,
+		"total":   total,
+	})
+}
+
+// GetOfficialByID godoc
+// GET /api/officials/:id
+func GetOfficialByID(c *gin.Context) {
+	id := c.Param("id")
+	var official models.Official
+
+	if err := config.DB.Preload("AffiliatedTeam").First(&official, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Official not found")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Official retrieved successfully", official)
+}
+
+// CreateOfficial godoc
+// POST /api/officials
+func CreateOfficial(c *gin.Context) {
+	var input OfficialInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	if !validateOfficialAffiliation(c, input) {
+		return
+	}
+
+	official := models.Official{
+		Name:             input.Name,
+		Phone:            input.Phone,
+		City:             input.City,
+		LicenseLevel:     input.LicenseLevel,
+		AffiliatedTeamID: input.AffiliatedTeamID,
+	}
+
+	if err := config.DB.Create(&official).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create official")
+		return
+	}
+
+	config.DB.Preload("AffiliatedTeam").First(&official, official.ID)
+	utils.SuccessResponse(c, http.StatusCreated, "Official created successfully", official)
+}
+
+// UpdateOfficial godoc
+// PUT /api/officials/:id
+func UpdateOfficial(c *gin.Context) {
+	id := c.Param("id")
+	var official models.Official
+
+	if err := config.DB.First(&official, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Official not found")
+		return
+	}
+
+	var input OfficialInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	if !validateOfficialAffiliation(c, input) {
+		return
+	}
+
+	official.Name = input.Name
+	official.Phone = input.Phone
+	official.City = input.City
+	official.LicenseLevel = input.LicenseLevel
+	official.AffiliatedTeamID = input.AffiliatedTeamID
+	official.AffiliatedTeam = nil
+
+	if err := config.DB.Save(&official).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update official")
+		return
+	}
+
+	config.DB.Preload("AffiliatedTeam").First(&official, official.ID)
+	utils.SuccessResponse(c, http.StatusOK, "Official updated successfully", official)
+}
+
+// DeleteOfficial godoc
+// DELETE /api/officials/:id — soft delete
+func DeleteOfficial(c *gin.Context) {
+	id := c.Param("id")
+	var official models.Official
+
+	if err := config.DB.First(&official, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Official not found")
+		return
+	}
+
+	if err := config.DB.Delete(&official).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete official")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Official deleted successfully", nil)
+}
+
+// GetOfficialStats godoc
+// GET /api/officials/:id/stats
+func GetOfficialStats(c *gin.Context) {
+	id := c.Param("id")
+	var official models.Official
+
+	if err := config.DB.First(&official, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Official not found")
+		return
+	}
+
+	stats := OfficialStats{
+		OfficialID:    official.ID,
+		MatchesByRole: make(map[models.OfficialRole]int),
+	}
+
+	var byRole []struct {
+		Role    models.OfficialRole
+		Matches int
+	}
+	completedMatchesQuery(config.DB).
+		Joins("JOIN match_officials ON match_officials.match_id = matches.id").
+		Where("match_officials.official_id = ?", official.ID).
+		Select("match_officials.role, COUNT(*) AS matches").
+		Group("match_officials.role").
+		Scan(&byRole)
+	for _, r := range byRole {
+		stats.MatchesByRole[r.Role] = r.Matches
+		stats.Matches += r.Matches
+	}
+
+	var upcoming int64
+	config.DB.Model(&models.Match{}).
+		Joins("JOIN match_officials ON match_officials.match_id = matches.id").
+		Where("match_officials.official_id = ? AND matches.status IN ?", official.ID, models.OpenMatchStatuses).
+		Count(&upcoming)
+	stats.UpcomingMatches = int(upcoming)
+
+	// Cards are credited to the referee of the match
+	var cards []struct {
+		Type  models.MatchEventType
+		Count int
+	}
+	completedMatchesQuery(config.DB).
+		Joins("JOIN match_officials ON match_officials.match_id = matches.id AND match_officials.role = ?", models.OfficialRoleReferee).
+		Joins("JOIN match_events ON match_events.match_result_id = match_results.id AND match_events.deleted_at IS NULL").
+		Where("match_officials.official_id = ? AND match_events.type IN ?", official.ID,
+			[]models.MatchEventType{models.EventYellowCard, models.EventSecondYellow, models.EventRedCard}).
+		Select("match_events.type, COUNT(*) AS count").
+		Group("match_events.type").
+		Scan(&cards)
+	for _, card := range cards {
+		switch card.Type {
+		case models.EventYellowCard:
+			stats.YellowCards = card.Count
+		case models.EventSecondYellow:
+			stats.SecondYellows = card.Count
+		case models.EventRedCard:
+			stats.RedCards = card.Count
+		}
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Official statistics retrieved successfully", stats)
+}
+
+// officialBookedMatch returns another match of the official that overlaps the match; cancelled
+// and postponed matches do not hold their kickoff
+func officialBookedMatch(db *gorm.DB, officialID uint, match models.Match) (models.Match, bool) {
+	var others []models.Match
+	db.Joins("JOIN match_officials ON match_officials.match_id = matches.id").
+		Where("match_officials.official_id = ? AND matches.id <> ? AND matches.status NOT IN ?",
+			officialID, match.ID, []models.MatchStatus{models.MatchStatusCancelled, models.MatchStatusPostponed}).
+		Find(&others)
+	for _, other := range others {
+		if matchesOverlap(match, other) {
+			return other, true
+		}
+	}
+	return models.Match{}, false
+}
+
+// officialConflict explains why the official cannot officiate the match: they are affiliated with
+// one of its teams or booked on another match that overlaps it. It returns "" when they can.
+func officialConflict(db *gorm.DB, official models.Official, match models.Match) string {
+	if official.AffiliatedTeamID != nil &&
+		(*official.AffiliatedTeamID == match.HomeTeamID || *official.AffiliatedTeamID == match.AwayTeamID) {
+		return fmt.Sprintf("Official %s is affiliated with one of the teams in this match", official.Name)
+	}
+	if other, booked := officialBookedMatch(db, official.ID, match); booked {
+		return fmt.Sprintf("Official %s is already assigned to match %d on %s %s", official.Name, other.ID, other.MatchDate, other.MatchTime)
+	}
+	return ""
+}
+
+// matchOfficialsConflict checks every official assigned to the match against its (new) teams and
+// kickoff, and returns the first conflict or ""
+func matchOfficialsConflict(db *gorm.DB, match models.Match) string {
+	var assignments []models.MatchOfficial
+	db.Preload("Official").Where("match_id = ?", match.ID).Order("id ASC").Find(&assignments)
+	for _, assignment := range assignments {
+		if assignment.Official == nil {
+			continue
+		}
+		if conflict := officialConflict(db, *assignment.Official, match); conflict != "" {
+			return conflict
+		}
+	}
+	return ""
+}
+
+// validateMatchOfficials writes a 409 and returns false when an official assigned to the match
+// cannot officiate it with its new teams or kickoff
+func validateMatchOfficials(c *gin.Context, match models.Match) bool {
+	if conflict := matchOfficialsConflict(config.DB, match); conflict != "" {
+		utils.ErrorResponse(c, http.StatusConflict, conflict+"; reassign the official first")
+		return false
+	}
+	return true
+}
+
+// GetMatchOfficials godoc
+// GET /api/matches/:id/officials
+func GetMatchOfficials(c *gin.Context) {
+	id := c.Param("id")
+	var match models.Match
+	if err := config.DB.First(&match, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Match not found")
+		return
+	}
+
+	var assignments []models.MatchOfficial
+	config.DB.Preload("Official").Where("match_id = ?", match.ID).Order("role ASC, id ASC").Find(&assignments)
+
+	utils.SuccessResponse(c, http.StatusOK, "Match officials retrieved successfully", assignments)
+}
+
+// AssignMatchOfficial godoc
+// POST /api/matches/:id/officials
+func AssignMatchOfficial(c *gin.Context) {
+	id := c.Param("id")
+	var match models.Match
+	if err := config.DB.First(&match, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Match not found")
+		return
+	}
+
+	if match.Status.IsDecided() || match.Status == models.MatchStatusCancelled {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Cannot assign officials to a "+string(match.Status)+" match")
+		return
+	}
+
+	var input MatchOfficialInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	limit, ok := models.OfficialRoleLimits[input.Role]
+	if !ok {
+		utils.ValidationErrorResponse(c, "Invalid role. Must be one of: referee, assistant_referee, match_commissioner")
+		return
+	}
+
+	var official models.Official
+	if err := config.DB.First(&official, input.OfficialID).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Official not found")
+		return
+	}
+
+	var assigned int64
+	config.DB.Model(&models.MatchOfficial{}).Where("match_id = ? AND official_id = ?", match.ID, official.ID).Count(&assigned)
+	if assigned > 0 {
+		utils.ErrorResponse(c, http.StatusConflict, "Official is already assigned to this match")
+		return
+	}
+
+	var inRole int64
+	config.DB.Model(&models.MatchOfficial{}).Where("match_id = ? AND role = ?", match.ID, input.Role).Count(&inRole)
+	if int(inRole) >= limit {
+		utils.ValidationErrorResponse(c, fmt.Sprintf("A match can have at most %d %s", limit, input.Role))
+		return
+	}
+
+	// The official cannot be affiliated with either team or booked on another match that overlaps this one
+	if conflict := officialConflict(config.DB, official, match); conflict != "" {
+		utils.ErrorResponse(c, http.StatusConflict, conflict)
+		return
+	}
+
+	assignment := models.MatchOfficial{
+		MatchID:    match.ID,
+		OfficialID: official.ID,
+		Role:       input.Role,
+	}
+	if err := config.DB.Create(&assignment).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to assign official")
+		return
+	}
+
+	config.DB.Preload("Official").First(&assignment, assignment.ID)
+	utils.SuccessResponse(c, http.StatusCreated, "Official assigned successfully", assignment)
+}
+
+// UnassignMatchOfficial godoc
+// DELETE /api/matches/:id/officials/:official_id
+func UnassignMatchOfficial(c *gin.Context) {
+	var assignment models.MatchOfficial
+	if err := config.DB.Where("match_id = ? AND official_id = ?", c.Param("id"), c.Param("official_id")).
+		First(&assignment).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Official is not assigned to this match")
+		return
+	}
+
+	if err := config.DB.Delete(&assignment).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to unassign official")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Official unassigned successfully", nil)
+}
@@ -17,6 +17,9 @@ type PlayerInput struct {
 	Weight       float64               `json:"weight" binding:"required,min=30,max=200"`
 	Position     models.PlayerPosition `json:"position" binding:"required"`
 	JerseyNumber int                   `json:"jersey_number" binding:"required,min=1,max=99"`
+
+	// Admins only, on create: allows registering outside a transfer window or during a roster freeze
+	OverrideReason string `json:"override_reason" binding:"omitempty,min=3,max=500"`
 }
 
 func isValidPosition(pos models.PlayerPosition) bool {
@@ -32,7 +35,7 @@ func isValidPosition(pos models.PlayerPosition) bool {
 // GET /api/players
 func GetAllPlayers(c *gin.Context) {
 	var players []models.Player
-	query := config.DB.Preload("Team")
+	query := config.DB.Model(&models.Player{})
 
 	if teamID := c.Query("team_id"); teamID != "" {
 		query = query.Where("team_id = ?", teamID)
@@ -40,10 +43,14 @@ func GetAllPlayers(c *gin.Context) {
 	if pos := c.Query("position"); pos != "" {
 		query = query.Where("position = ?", pos)
 	}
+	if seasonID := c.Query("season_id"); seasonID != "" {
+		query = query.Where("team_id IN (?)",
+			config.DB.Table("season_teams").Select("team_id").Where("season_id = ?", seasonID))
+	}
 
 	var total int64
-	config.DB.Model(&models.Player{}).Count(&total)
-	query.Find(&players)
+	query.Count(&total)
+	query.Preload("Team").Find(&players)
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
@@ -54,7 +61,7 @@ func GetAllPlayers(c *gin.Context) {
 }
 
 // GetPlayerByID godoc
-// GET /api/players/:id
+// GET /api/players/:id — includes season and career appearances and minutes
 func GetPlayerByID(c *gin.Context) {
 	id := c.Param("id")
 	var player models.Player
@@ -64,7 +71,10 @@ func GetPlayerByID(c *gin.Context) {
 		return
 	}
 
-	utils.SuccessResponse(c, http.StatusOK, "Player retrieved successfully", player)
+	utils.SuccessResponse(c, http.StatusOK, "Player retrieved successfully", PlayerDetail{
+		Player: player,
+		Stats:  playerStats(player.ID),
+	})
 }
 
 // GetPlayersByTeam godoc
@@ -113,6 +123,10 @@ func CreatePlayer(c *gin.Context) {
 		return
 	}
 
+	if !checkRosterChange(c, config.DB, []models.Team{team}, today(), input.OverrideReason) {
+		return
+	}
+
 	player := models.Player{
 		TeamID:       input.TeamID,
 		Name:         input.Name,
@@ -122,11 +136,30 @@ func CreatePlayer(c *gin.Context) {
 		JerseyNumber: input.JerseyNumber,
 	}
 
-	if err := config.DB.Create(&player).Error; err != nil {
+	tx := config.DB.Begin()
+
+	if err := tx.Create(&player).Error; err != nil {
+		tx.Rollback()
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create player")
 		return
 	}
 
+	// The membership history starts with the registration
+	membership := models.TeamMembership{
+		PlayerID:     player.ID,
+		TeamID:       player.TeamID,
+		FromDate:     today(),
+		JerseyNumber: player.JerseyNumber,
+	}
+	recordOverride(c, &membership, input.OverrideReason)
+	if err := tx.Create(&membership).Error; err != nil {
+		tx.Rollback()
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create membership")
+		return
+	}
+
+	tx.Commit()
+
 	config.DB.Preload("Team").First(&player, player.ID)
 	utils.SuccessResponse(c, http.StatusCreated, "Player created successfully", player)
 }
@@ -153,10 +186,9 @@ func UpdatePlayer(c *gin.Context) {
 		return
 	}
 
-	// Validate team exists
-	var team models.Team
-	if err := config.DB.First(&team, input.TeamID).Error; err != nil {
-		utils.ErrorResponse(c, http.StatusNotFound, "Team not found")
+	// Moving to another team is a transfer, so the membership history stays intact
+	if input.TeamID != player.TeamID {
+		utils.ValidationErrorResponse(c, "Use POST /api/players/:id/transfer to move a player to another team")
 		return
 	}
 
@@ -168,18 +200,31 @@ func UpdatePlayer(c *gin.Context) {
 		return
 	}
 
-	player.TeamID = input.TeamID
 	player.Name = input.Name
 	player.Height = input.Height
 	player.Weight = input.Weight
 	player.Position = input.Position
 	player.JerseyNumber = input.JerseyNumber
 
-	if err := config.DB.Save(&player).Error; err != nil {
+	tx := config.DB.Begin()
+
+	if err := tx.Save(&player).Error; err != nil {
+		tx.Rollback()
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update player")
 		return
 	}
 
+	// A new number applies to the current spell at the team
+	if err := tx.Model(&models.TeamMembership{}).
+		Where("player_id = ? AND to_date IS NULL", player.ID).
+		Update("jersey_number", player.JerseyNumber).Error; err != nil {
+		tx.Rollback()
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update membership")
+		return
+	}
+
+	tx.Commit()
+
 	config.DB.Preload("Team").First(&player, player.ID)
 	utils.SuccessResponse(c, http.StatusOK, "Player updated successfully", player)
 }
@@ -2,33 +2,145 @@ package handlers
 
 import (
 	"net/http"
+	"sort"
+	"strconv"
+	"time"
 
 	"ayoindo/config"
 	"ayoindo/models"
 	"ayoindo/utils"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type TopScorer struct {
 	PlayerID   uint   `json:"player_id"`
 	PlayerName string `json:"player_name"`
+	TeamID     uint   `json:"team_id"` // team on the match date
 	Goals      int    `json:"goals"`
 }
 
+type TopAssist struct {
+	PlayerID   uint   `json:"player_id"`
+	PlayerName string `json:"player_name"`
+	Assists    int    `json:"assists"`
+}
+
 type MatchReportData struct {
-	MatchID           uint          `json:"match_id"`
-	MatchDate         string        `json:"match_date"`
-	MatchTime         string        `json:"match_time"`
-	HomeTeam          *models.Team  `json:"home_team"`
-	AwayTeam          *models.Team  `json:"away_team"`
-	HomeScore         int           `json:"home_score"`
-	AwayScore         int           `json:"away_score"`
-	FinalStatus       string        `json:"final_status"`
-	Goals             []models.Goal `json:"goals"`
-	TopScorers        []TopScorer   `json:"top_scorers"`
-	HomeTeamTotalWins int64         `json:"home_team_total_wins"`
-	AwayTeamTotalWins int64         `json:"away_team_total_wins"`
+	MatchID             uint                      `json:"match_id"`
+	MatchDate           string                    `json:"match_date"`
+	MatchTime           string                    `json:"match_time"`
+	KickoffAt           *time.Time                `json:"kickoff_at"`
+	Timezone            string                    `json:"timezone"`
+	HomeTeam            *models.Team              `json:"home_team"`
+	AwayTeam            *models.Team              `json:"away_team"`
+	HomeScore           int                       `json:"home_score"`
+	AwayScore           int                       `json:"away_score"`
+	HomeRegulationScore int                       `json:"home_regulation_score"`
+	AwayRegulationScore int                       `json:"away_regulation_score"`
+	ExtraTime           bool                      `json:"extra_time"`
+	HomeExtraTimeScore  int                       `json:"home_extra_time_score"`
+	AwayExtraTimeScore  int                       `json:"away_extra_time_score"`
+	HomePenaltyScore    *int                      `json:"home_penalty_score"`
+	AwayPenaltyScore    *int                      `json:"away_penalty_score"`
+	DecidedBy           string                    `json:"decided_by"`
+	ResultType          models.ResultType         `json:"result_type"`
+	ForfeitingTeamID    *uint                     `json:"forfeiting_team_id,omitempty"`
+	ForfeitReason       string                    `json:"forfeit_reason,omitempty"`
+	FinalStatus         string                    `json:"final_status"`
+	Goals               []models.Goal             `json:"goals"`
+	ShootoutKicks       []models.ShootoutKick     `json:"shootout_kicks"`
+	Events              []models.MatchEvent       `json:"events"` // chronological timeline
+	TopScorers          []TopScorer               `json:"top_scorers"`
+	Assists             []TopAssist               `json:"assists"` // assists per player in this match, most first
+	TeamStats           []models.TeamMatchStats   `json:"team_stats"`
+	PlayerStats         []models.PlayerMatchStats `json:"player_stats"`
+	HomeTeamTotalWins   int64                     `json:"home_team_total_wins"`
+	AwayTeamTotalWins   int64                     `json:"away_team_total_wins"`
+}
+
+// How a result was decided
+const (
+	DecidedByRegulation = "regulation"
+	DecidedByExtraTime  = "extra_time"
+	DecidedByPenalties  = "penalties"
+	DecidedByForfeit    = "forfeit"
+)
+
+// SQL counterparts of resultWinner for counting wins on completedMatchesQuery
+const (
+	homeWinCondition = "(match_results.home_score > match_results.away_score OR " +
+		"(match_results.home_score = match_results.away_score AND match_results.home_penalty_score > match_results.away_penalty_score))"
+	awayWinCondition = "(match_results.away_score > match_results.home_score OR " +
+		"(match_results.home_score = match_results.away_score AND match_results.away_penalty_score > match_results.home_penalty_score))"
+)
+
+// resultWinner tells which side won, falling back to the shootout when the score is level.
+// homeWin and awayWin are both false for a draw.
+func resultWinner(r *models.MatchResult) (homeWin, awayWin bool, decidedBy string) {
+	decidedBy = DecidedByRegulation
+	if r.ExtraTime {
+		decidedBy = DecidedByExtraTime
+	}
+	if r.ResultType == models.ResultTypeForfeit {
+		decidedBy = DecidedByForfeit
+	}
+
+	switch {
+	case r.HomeScore > r.AwayScore:
+		return true, false, decidedBy
+	case r.AwayScore > r.HomeScore:
+		return false, true, decidedBy
+	}
+
+	if r.HomePenaltyScore != nil && r.AwayPenaltyScore != nil {
+		return *r.HomePenaltyScore > *r.AwayPenaltyScore, *r.AwayPenaltyScore > *r.HomePenaltyScore, DecidedByPenalties
+	}
+	return false, false, decidedBy
+}
+
+// finalStatusLabel renders the outcome of a result for reports
+func finalStatusLabel(r *models.MatchResult) string {
+	homeWin, awayWin, decidedBy := resultWinner(r)
+
+	var status string
+	switch {
+	case homeWin:
+		status = "Tim Home Menang"
+	case awayWin:
+		status = "Tim Away Menang"
+	default:
+		return "Draw"
+	}
+
+	switch decidedBy {
+	case DecidedByExtraTime:
+		status += " (Perpanjangan Waktu)"
+	case DecidedByPenalties:
+		status += " (Adu Penalti)"
+	case DecidedByForfeit:
+		status += " (Walkover)"
+	}
+	return status
+}
+
+// completedMatchesQuery returns a query over completed and forfeited matches joined with their results.
+// Every aggregate over finished games (win counts, standings) starts from this query.
+// Pass a transaction to see its uncommitted results.
+func completedMatchesQuery(db *gorm.DB) *gorm.DB {
+	return db.Model(&models.Match{}).
+		Joins("JOIN match_results ON match_results.match_id = matches.id AND match_results.deleted_at IS NULL").
+		Where("matches.deleted_at IS NULL AND matches.status IN ?", models.DecidedMatchStatuses)
+}
+
+// chronologicalOrder sorts matches by kickoff; the id only breaks ties between simultaneous kickoffs
+const chronologicalOrder = "matches.kickoff_at ASC, matches.id ASC"
+
+// playedUpTo restricts a match query to matches kicked off no later than the given match, the match included
+func playedUpTo(db *gorm.DB, match models.Match) *gorm.DB {
+	return db.Where("(matches.kickoff_at < ? OR (matches.kickoff_at = ? AND matches.id <= ?))",
+		match.KickoffAt, match.KickoffAt, match.ID)
 }
 
 // GetMatchReport godoc
@@ -46,36 +158,39 @@ func GetMatchReport(c *gin.Context) {
 		return
 	}
 
-	if match.Status != models.MatchStatusCompleted {
+	if !match.Status.IsDecided() {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Match has not been completed yet")
 		return
 	}
 
-	// Load result with goals
+	// Load result with goals, shootout and timeline
 	var result models.MatchResult
 	if err := config.DB.
 		Preload("Goals").
 		Preload("Goals.Player").
+		Preload("Goals.AssistPlayer").
+		Preload("ShootoutKicks", orderShootoutKicks).
+		Preload("ShootoutKicks.Player").
+		Preload("Events", orderMatchEvents).
+		Preload("Events.Player").
+		Preload("Events.RelatedPlayer").
 		Where("match_id = ?", match.ID).
 		First(&result).Error; err != nil {
 		utils.ErrorResponse(c, http.StatusNotFound, "Match result not found")
 		return
 	}
 
-	// Determine final status
-	var finalStatus string
-	switch {
-	case result.HomeScore > result.AwayScore:
-		finalStatus = "Tim Home Menang"
-	case result.AwayScore > result.HomeScore:
-		finalStatus = "Tim Away Menang"
-	default:
-		finalStatus = "Draw"
-	}
+	// Determine final status, including extra time and shootout
+	_, _, decidedBy := resultWinner(&result)
+	finalStatus := finalStatusLabel(&result)
 
-	// Calculate top scorers for this match
+	// Calculate top scorers for this match; own goals are not credited to the scorer,
+	// and a forfeit has no goals and therefore no scorers
 	scorerMap := make(map[uint]*TopScorer)
 	for _, goal := range result.Goals {
+		if goal.Kind == models.GoalKindOwnGoal {
+			continue
+		}
 		if _, exists := scorerMap[goal.PlayerID]; !exists {
 			name := ""
 			if goal.Player != nil {
@@ -84,6 +199,7 @@ func GetMatchReport(c *gin.Context) {
 			scorerMap[goal.PlayerID] = &TopScorer{
 				PlayerID:   goal.PlayerID,
 				PlayerName: name,
+				TeamID:     goal.TeamID,
 				Goals:      0,
 			}
 		}
@@ -105,55 +221,96 @@ func GetMatchReport(c *gin.Context) {
 		}
 	}
 
-	// Accumulate home team wins: all completed matches up to this match_id where home team won
-	// Win for home: home_score > away_score in matches where home_team_id = match.HomeTeamID
+	// Count assists for this match
+	assistMap := make(map[uint]*TopAssist)
+	var assists []TopAssist
+	for _, goal := range result.Goals {
+		if goal.AssistPlayerID == nil {
+			continue
+		}
+		if _, exists := assistMap[*goal.AssistPlayerID]; !exists {
+			name := ""
+			if goal.AssistPlayer != nil {
+				name = goal.AssistPlayer.Name
+			}
+			assistMap[*goal.AssistPlayerID] = &TopAssist{
+				PlayerID:   *goal.AssistPlayerID,
+				PlayerName: name,
+			}
+		}
+		assistMap[*goal.AssistPlayerID].Assists++
+	}
+	for _, a := range assistMap {
+		assists = append(assists, *a)
+	}
+	sort.Slice(assists, func(i, j int) bool {
+		if assists[i].Assists != assists[j].Assists {
+			return assists[i].Assists > assists[j].Assists
+		}
+		return assists[i].PlayerName < assists[j].PlayerName
+	})
+
+	// Accumulate home team wins: all completed matches kicked off up to this match where home team won
+	// Win for home: higher score, or level score and more penalties, in matches where home_team_id = match.HomeTeamID
 	var homeTeamWins int64
-	config.DB.Model(&models.Match{}).
-		Joins("JOIN match_results ON match_results.match_id = matches.id AND match_results.deleted_at IS NULL").
-		Where("matches.id <= ? AND matches.deleted_at IS NULL AND matches.status = ? AND matches.home_team_id = ? AND match_results.home_score > match_results.away_score",
-			match.ID, models.MatchStatusCompleted, match.HomeTeamID).
+	playedUpTo(completedMatchesQuery(config.DB), match).
+		Where("matches.home_team_id = ? AND "+homeWinCondition, match.HomeTeamID).
 		Count(&homeTeamWins)
 
 	// Also count when the same team was away and won
 	var homeTeamWinsAsAway int64
-	config.DB.Model(&models.Match{}).
-		Joins("JOIN match_results ON match_results.match_id = matches.id AND match_results.deleted_at IS NULL").
-		Where("matches.id <= ? AND matches.deleted_at IS NULL AND matches.status = ? AND matches.away_team_id = ? AND match_results.away_score > match_results.home_score",
-			match.ID, models.MatchStatusCompleted, match.HomeTeamID).
+	playedUpTo(completedMatchesQuery(config.DB), match).
+		Where("matches.away_team_id = ? AND "+awayWinCondition, match.HomeTeamID).
 		Count(&homeTeamWinsAsAway)
 
 	homeTeamTotalWins := homeTeamWins + homeTeamWinsAsAway
 
 	// Accumulate away team wins
 	var awayTeamWins int64
-	config.DB.Model(&models.Match{}).
-		Joins("JOIN match_results ON match_results.match_id = matches.id AND match_results.deleted_at IS NULL").
-		Where("matches.id <= ? AND matches.deleted_at IS NULL AND matches.status = ? AND matches.home_team_id = ? AND match_results.home_score > match_results.away_score",
-			match.ID, models.MatchStatusCompleted, match.AwayTeamID).
+	playedUpTo(completedMatchesQuery(config.DB), match).
+		Where("matches.home_team_id = ? AND "+homeWinCondition, match.AwayTeamID).
 		Count(&awayTeamWins)
 
 	var awayTeamWinsAsAway int64
-	config.DB.Model(&models.Match{}).
-		Joins("JOIN match_results ON match_results.match_id = matches.id AND match_results.deleted_at IS NULL").
-		Where("matches.id <= ? AND matches.deleted_at IS NULL AND matches.status = ? AND matches.away_team_id = ? AND match_results.away_score > match_results.home_score",
-			match.ID, models.MatchStatusCompleted, match.AwayTeamID).
+	playedUpTo(completedMatchesQuery(config.DB), match).
+		Where("matches.away_team_id = ? AND "+awayWinCondition, match.AwayTeamID).
 		Count(&awayTeamWinsAsAway)
 
 	awayTeamTotalWins := awayTeamWins + awayTeamWinsAsAway
 
+	stats := loadMatchStats(match.ID)
+
 	report := MatchReportData{
-		MatchID:           match.ID,
-		MatchDate:         match.MatchDate,
-		MatchTime:         match.MatchTime,
-		HomeTeam:          match.HomeTeam,
-		AwayTeam:          match.AwayTeam,
-		HomeScore:         result.HomeScore,
-		AwayScore:         result.AwayScore,
-		FinalStatus:       finalStatus,
-		Goals:             result.Goals,
-		TopScorers:        topScorers,
-		HomeTeamTotalWins: homeTeamTotalWins,
-		AwayTeamTotalWins: awayTeamTotalWins,
+		MatchID:             match.ID,
+		MatchDate:           match.MatchDate,
+		MatchTime:           match.MatchTime,
+		KickoffAt:           match.KickoffAt,
+		Timezone:            match.Timezone,
+		HomeTeam:            match.HomeTeam,
+		AwayTeam:            match.AwayTeam,
+		HomeScore:           result.HomeScore,
+		AwayScore:           result.AwayScore,
+		HomeRegulationScore: result.HomeScore - result.HomeExtraTimeScore,
+		AwayRegulationScore: result.AwayScore - result.AwayExtraTimeScore,
+		ExtraTime:           result.ExtraTime,
+		HomeExtraTimeScore:  result.HomeExtraTimeScore,
+		AwayExtraTimeScore:  result.AwayExtraTimeScore,
+		HomePenaltyScore:    result.HomePenaltyScore,
+		AwayPenaltyScore:    result.AwayPenaltyScore,
+		DecidedBy:           decidedBy,
+		ResultType:          result.ResultType,
+		ForfeitingTeamID:    result.ForfeitingTeamID,
+		ForfeitReason:       result.ForfeitReason,
+		FinalStatus:         finalStatus,
+		Goals:               result.Goals,
+		ShootoutKicks:       result.ShootoutKicks,
+		Events:              result.Events,
+		TopScorers:          topScorers,
+		Assists:             assists,
+		TeamStats:           stats.Teams,
+		PlayerStats:         stats.Players,
+		HomeTeamTotalWins:   homeTeamTotalWins,
+		AwayTeamTotalWins:   awayTeamTotalWins,
 	}
 
 	utils.SuccessResponse(c, http.StatusOK, "Match report retrieved successfully", report)
@@ -163,25 +320,35 @@ func GetMatchReport(c *gin.Context) {
 // GET /api/reports/matches
 func GetAllReports(c *gin.Context) {
 	var matches []models.Match
-	config.DB.
+	query := config.DB.
 		Preload("HomeTeam").
 		Preload("AwayTeam").
 		Preload("MatchResult").
 		Preload("MatchResult.Goals").
 		Preload("MatchResult.Goals.Player").
-		Where("status = ?", models.MatchStatusCompleted).
-		Order("match_date ASC, match_time ASC").
-		Find(&matches)
+		Preload("MatchResult.Goals.AssistPlayer").
+		Where("status IN ?", models.DecidedMatchStatuses)
+
+	if seasonID := c.Query("season_id"); seasonID != "" {
+		query = query.Where("season_id = ?", seasonID)
+	}
+
+	query.Order("kickoff_at ASC, id ASC").Find(&matches)
 
 	type ReportSummary struct {
-		MatchID     uint         `json:"match_id"`
-		MatchDate   string       `json:"match_date"`
-		MatchTime   string       `json:"match_time"`
-		HomeTeam    *models.Team `json:"home_team"`
-		AwayTeam    *models.Team `json:"away_team"`
-		HomeScore   int          `json:"home_score"`
-		AwayScore   int          `json:"away_score"`
-		FinalStatus string       `json:"final_status"`
+		MatchID     uint              `json:"match_id"`
+		MatchDate   string            `json:"match_date"`
+		MatchTime   string            `json:"match_time"`
+		KickoffAt   *time.Time        `json:"kickoff_at"`
+		HomeTeam    *models.Team      `json:"home_team"`
+		AwayTeam    *models.Team      `json:"away_team"`
+		HomeScore   int               `json:"home_score"`
+		AwayScore   int               `json:"away_score"`
+		ResultType  models.ResultType `json:"result_type"`
+		ExtraTime   bool              `json:"extra_time"`
+		HomePenalty *int              `json:"home_penalty_score"`
+		AwayPenalty *int              `json:"away_penalty_score"`
+		FinalStatus string            `json:"final_status"`
 	}
 
 	var reports []ReportSummary
@@ -189,21 +356,20 @@ func GetAllReports(c *gin.Context) {
 		if m.MatchResult == nil {
 			continue
 		}
-		status := "Draw"
-		if m.MatchResult.HomeScore > m.MatchResult.AwayScore {
-			status = "Tim Home Menang"
-		} else if m.MatchResult.AwayScore > m.MatchResult.HomeScore {
-			status = "Tim Away Menang"
-		}
 		reports = append(reports, ReportSummary{
 			MatchID:     m.ID,
 			MatchDate:   m.MatchDate,
 			MatchTime:   m.MatchTime,
+			KickoffAt:   m.KickoffAt,
 			HomeTeam:    m.HomeTeam,
 			AwayTeam:    m.AwayTeam,
 			HomeScore:   m.MatchResult.HomeScore,
 			AwayScore:   m.MatchResult.AwayScore,
-			FinalStatus: status,
+			ResultType:  m.MatchResult.ResultType,
+			ExtraTime:   m.MatchResult.ExtraTime,
+			HomePenalty: m.MatchResult.HomePenaltyScore,
+			AwayPenalty: m.MatchResult.AwayPenaltyScore,
+			FinalStatus: finalStatusLabel(m.MatchResult),
 		})
 	}
 
@@ -214,3 +380,36 @@ func GetAllReports(c *gin.Context) {
 		"total":   len(reports),
 	})
 }
+
+// GetSeasonAssists godoc
+// GET /api/seasons/:id/assists — assist leaderboard over the completed matches of a season
+func GetSeasonAssists(c *gin.Context) {
+	id := c.Param("id")
+	var season models.Season
+	if err := config.DB.First(&season, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Season not found")
+		return
+	}
+
+	query := completedMatchesQuery(config.DB).
+		Joins("JOIN goals ON goals.match_result_id = match_results.id AND goals.deleted_at IS NULL").
+		Joins("JOIN players ON players.id = goals.assist_player_id").
+		Where("matches.season_id = ?", season.ID).
+		Select("goals.assist_player_id AS player_id, players.name AS player_name, COUNT(*) AS assists").
+		Group("goals.assist_player_id, players.name").
+		Order("assists DESC, players.name ASC")
+
+	if limit := c.Query("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 {
+			utils.ValidationErrorResponse(c, "limit must be a positive number")
+			return
+		}
+		query = query.Limit(n)
+	}
+
+	leaders := []TopAssist{}
+	query.Scan(&leaders)
+
+	utils.SuccessResponse(c, http.StatusOK, "Assist leaderboard retrieved successfully", leaders)
+}
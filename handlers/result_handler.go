@@ -1,24 +1,424 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"sort"
 
 	"ayoindo/config"
 	"ayoindo/models"
 	"ayoindo/utils"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type GoalInput struct {
+	PlayerID       uint            `json:"player_id" binding:"required"`
+	Minute         int             `json:"minute" binding:"required,min=1,max=120"`
+	Kind           models.GoalKind `json:"kind"`             // normal (default), penalty or own_goal
+	AssistPlayerID *uint           `json:"assist_player_id"` // optional, a teammate of the scorer
+}
+
+// goalKind returns the kind of a submitted goal, normal when omitted
+func (g GoalInput) goalKind() models.GoalKind {
+	if g.Kind == "" {
+		return models.GoalKindNormal
+	}
+	return g.Kind
+}
+
+// ExtraTimeInput holds the goals scored after regulation time (90 minutes unless the competition
+// sets another match length); they are already part of the final scores
+type ExtraTimeInput struct {
+	HomeScore int `json:"home_score" binding:"min=0"`
+	AwayScore int `json:"away_score" binding:"min=0"`
+}
+
+type ShootoutKickInput struct {
 	PlayerID uint `json:"player_id" binding:"required"`
-	Minute   int  `json:"minute" binding:"required,min=1,max=120"`
+	Scored   bool `json:"scored"`
+}
+
+// MatchEventInput is a timeline entry other than a goal; goal events are taken from goals.
+// For a substitution player_id comes on and related_player_id goes off.
+type MatchEventInput struct {
+	Type            models.MatchEventType `json:"type" binding:"required"`
+	Minute          int                   `json:"minute" binding:"required,min=1,max=120"`
+	PlayerID        *uint                 `json:"player_id"`
+	RelatedPlayerID *uint                 `json:"related_player_id"`
+	TeamID          *uint                 `json:"team_id"` // only for var/other events without a player
+	Description     string                `json:"description" binding:"max=255"`
 }
 
+// ForfeitInput records a walkover: the team that did not play loses by the awarded score
+type ForfeitInput struct {
+	TeamID       uint   `json:"team_id" binding:"required"` // the forfeiting team
+	Reason       string `json:"reason" binding:"required,min=3,max=500"`
+	AwardedScore int    `json:"awarded_score" binding:"omitempty,min=1,max=20"` // defaults to 3
+}
+
+// defaultForfeitScore is the score awarded to the opponent of a forfeiting team
+const defaultForfeitScore = 3
+
 type MatchResultInput struct {
-	HomeScore int         `json:"home_score" binding:"min=0"`
-	AwayScore int         `json:"away_score" binding:"min=0"`
-	Goals     []GoalInput `json:"goals"`
+	HomeScore int                 `json:"home_score" binding:"min=0"` // final score, including extra time
+	AwayScore int                 `json:"away_score" binding:"min=0"`
+	Goals     []GoalInput         `json:"goals"`
+	ExtraTime *ExtraTimeInput     `json:"extra_time"`
+	Shootout  []ShootoutKickInput `json:"shootout" binding:"dive"` // in the order the kicks were taken
+	Events    []MatchEventInput   `json:"events" binding:"dive"`
+	Forfeit   *ForfeitInput       `json:"forfeit"` // walkover instead of a played match; scores and goals are ignored
+}
+
+// orderMatchEvents preloads match events in chronological order
+func orderMatchEvents(db *gorm.DB) *gorm.DB {
+	return db.Order("minute ASC, id ASC")
+}
+
+// orderShootoutKicks preloads shootout kicks in the order they were taken
+func orderShootoutKicks(db *gorm.DB) *gorm.DB {
+	return db.Order("kick_number ASC")
+}
+
+// resultPlayerIDs lists every player named in a result: scorers, assisters, shootout takers and the
+// players of the events, each once in the order they are first named
+func resultPlayerIDs(input MatchResultInput) []uint {
+	seen := make(map[uint]bool)
+	var ids []uint
+	add := func(id *uint) {
+		if id != nil && !seen[*id] {
+			seen[*id] = true
+			ids = append(ids, *id)
+		}
+	}
+	for i := range input.Goals {
+		add(&input.Goals[i].PlayerID)
+		add(input.Goals[i].AssistPlayerID)
+	}
+	for i := range input.Shootout {
+		add(&input.Shootout[i].PlayerID)
+	}
+	for _, e := range input.Events {
+		add(e.PlayerID)
+		add(e.RelatedPlayerID)
+	}
+	return ids
+}
+
+// goalMinuteError explains why a goal cannot have been scored in the minute of a match of the given
+// regulation length, or returns "": goals after regulation time need extra time, which lasts
+// models.ExtraTimeLength minutes
+func goalMinuteError(minute, length int, extraTime bool) string {
+	if minute > length && !extraTime {
+		return fmt.Sprintf("Goal in minute %d is after regulation time (%d minutes) but no extra time was played", minute, length)
+	}
+	if end := length + models.ExtraTimeLength; minute > end {
+		return fmt.Sprintf("Goal in minute %d is after the end of extra time (minute %d)", minute, end)
+	}
+	return ""
+}
+
+// buildPlayedResult validates the goals, extra time and shootout of a played match of the given regulation length
+// and returns the result to save. It writes the error response and returns false on invalid input.
+func buildPlayedResult(c *gin.Context, match models.Match, input MatchResultInput, length int) (models.MatchResult, []models.ShootoutKick, bool) {
+	// Validate goals: each player must belong to one of the two teams
+	// and goal count must match scores. An own goal counts for the other team.
+	homeGoalCount := 0
+	awayGoalCount := 0
+	homeExtraTimeGoals := 0
+	awayExtraTimeGoals := 0
+
+	for _, g := range input.Goals {
+		if msg := goalMinuteError(g.Minute, length, input.ExtraTime != nil); msg != "" {
+			utils.ValidationErrorResponse(c, msg)
+			return models.MatchResult{}, nil, false
+		}
+		player, err := loadMatchPlayer(config.DB, g.PlayerID, match)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusNotFound, fmt.Sprintf("Player not found: player_id %d", g.PlayerID))
+			return models.MatchResult{}, nil, false
+		}
+		if player.TeamID != match.HomeTeamID && player.TeamID != match.AwayTeamID {
+			utils.ValidationErrorResponse(c, "Player does not belong to either team in this match")
+			return models.MatchResult{}, nil, false
+		}
+		if !g.goalKind().IsValid() {
+			utils.ValidationErrorResponse(c, "Invalid goal kind. Must be one of: normal, penalty, own_goal")
+			return models.MatchResult{}, nil, false
+		}
+		if g.AssistPlayerID != nil {
+			if g.goalKind() == models.GoalKindOwnGoal {
+				utils.ValidationErrorResponse(c, "An own goal cannot have an assist")
+				return models.MatchResult{}, nil, false
+			}
+			if *g.AssistPlayerID == player.ID {
+				utils.ValidationErrorResponse(c, "A player cannot assist their own goal")
+				return models.MatchResult{}, nil, false
+			}
+			assist, err := loadMatchPlayer(config.DB, *g.AssistPlayerID, match)
+			if err != nil {
+				utils.ErrorResponse(c, http.StatusNotFound, fmt.Sprintf("Player not found: assist_player_id %d", *g.AssistPlayerID))
+				return models.MatchResult{}, nil, false
+			}
+			if assist.TeamID != player.TeamID {
+				utils.ValidationErrorResponse(c, "The assisting player must be on the same team as the scorer")
+				return models.MatchResult{}, nil, false
+			}
+		}
+		forHome := player.TeamID == match.HomeTeamID
+		if g.goalKind() == models.GoalKindOwnGoal {
+			forHome = !forHome
+		}
+		if forHome {
+			homeGoalCount++
+			if g.Minute > length {
+				homeExtraTimeGoals++
+			}
+		} else {
+			awayGoalCount++
+			if g.Minute > length {
+				awayExtraTimeGoals++
+			}
+		}
+	}
+
+	if homeGoalCount != input.HomeScore || awayGoalCount != input.AwayScore {
+		utils.ValidationErrorResponse(c, "Number of goals does not match the provided scores")
+		return models.MatchResult{}, nil, false
+	}
+
+	candidate := models.MatchResult{
+		MatchID:    match.ID,
+		ResultType: models.ResultTypeNormal,
+		HomeScore:  input.HomeScore,
+		AwayScore:  input.AwayScore,
+	}
+
+	// Extra time: goals after regulation time must match the extra time scores,
+	// and extra time is only played from a level score
+	if input.ExtraTime != nil {
+		if input.ExtraTime.HomeScore > input.HomeScore || input.ExtraTime.AwayScore > input.AwayScore {
+			utils.ValidationErrorResponse(c, "Extra time scores cannot exceed the final scores")
+			return models.MatchResult{}, nil, false
+		}
+		if input.HomeScore-input.ExtraTime.HomeScore != input.AwayScore-input.ExtraTime.AwayScore {
+			utils.ValidationErrorResponse(c, "Extra time is only played when the score is level after regulation time")
+			return models.MatchResult{}, nil, false
+		}
+		if homeExtraTimeGoals != input.ExtraTime.HomeScore || awayExtraTimeGoals != input.ExtraTime.AwayScore {
+			utils.ValidationErrorResponse(c, fmt.Sprintf("Number of goals after minute %d does not match the extra time scores", length))
+			return models.MatchResult{}, nil, false
+		}
+		candidate.ExtraTime = true
+		candidate.HomeExtraTimeScore = input.ExtraTime.HomeScore
+		candidate.AwayExtraTimeScore = input.ExtraTime.AwayScore
+	}
+
+	// Penalty shootout: only from a level score, and it must produce a winner
+	var kicks []models.ShootoutKick
+	if len(input.Shootout) > 0 {
+		if input.HomeScore != input.AwayScore {
+			utils.ValidationErrorResponse(c, "A penalty shootout is only held when the score is level")
+			return models.MatchResult{}, nil, false
+		}
+
+		homePenalties, awayPenalties := 0, 0
+		for i, k := range input.Shootout {
+			player, err := loadMatchPlayer(config.DB, k.PlayerID, match)
+			if err != nil {
+				utils.ErrorResponse(c, http.StatusNotFound, fmt.Sprintf("Player not found: player_id %d", k.PlayerID))
+				return models.MatchResult{}, nil, false
+			}
+			if player.TeamID != match.HomeTeamID && player.TeamID != match.AwayTeamID {
+				utils.ValidationErrorResponse(c, "Penalty taker does not belong to either team in this match")
+				return models.MatchResult{}, nil, false
+			}
+			if k.Scored {
+				if player.TeamID == match.HomeTeamID {
+					homePenalties++
+				} else {
+					awayPenalties++
+				}
+			}
+			kicks = append(kicks, models.ShootoutKick{
+				KickNumber: i + 1,
+				PlayerID:   player.ID,
+				TeamID:     player.TeamID,
+				Scored:     k.Scored,
+			})
+		}
+
+		if homePenalties == awayPenalties {
+			utils.ValidationErrorResponse(c, "The penalty shootout must produce a winner")
+			return models.MatchResult{}, nil, false
+		}
+		candidate.HomePenaltyScore = &homePenalties
+		candidate.AwayPenaltyScore = &awayPenalties
+	}
+
+	return candidate, kicks, true
+}
+
+// buildMatchEvents loads the players named in the goals and events and merges the events with a
+// goal event per goal. It writes the error response and returns false on invalid input.
+func buildMatchEvents(c *gin.Context, match models.Match, input MatchResultInput) ([]models.MatchEvent, bool) {
+	ids := make([]uint, 0, len(input.Goals)+2*len(input.Events))
+	for _, g := range input.Goals {
+		ids = append(ids, g.PlayerID)
+	}
+	for _, e := range input.Events {
+		if e.PlayerID != nil {
+			ids = append(ids, *e.PlayerID)
+		}
+		if e.RelatedPlayerID != nil {
+			ids = append(ids, *e.RelatedPlayerID)
+		}
+	}
+
+	// Each player is loaded with their team on the match date
+	players := make(map[uint]models.Player)
+	for _, id := range ids {
+		if _, ok := players[id]; ok {
+			continue
+		}
+		player, err := loadMatchPlayer(config.DB, id, match)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusNotFound, fmt.Sprintf("Player not found: player_id %d", id))
+			return nil, false
+		}
+		if player.TeamID != match.HomeTeamID && player.TeamID != match.AwayTeamID {
+			utils.ValidationErrorResponse(c, "Event player does not belong to either team in this match")
+			return nil, false
+		}
+		players[id] = player
+	}
+
+	events, msg := mergeMatchEvents(match, input, players)
+	if msg != "" {
+		utils.ValidationErrorResponse(c, msg)
+		return nil, false
+	}
+	return events, true
+}
+
+// mergeMatchEvents validates the submitted events and merges them with a goal event per goal,
+// sorted by minute. players holds every player named in the input, with their team on the match
+// date. It returns a validation message when the input is invalid.
+func mergeMatchEvents(match models.Match, input MatchResultInput, players map[uint]models.Player) ([]models.MatchEvent, string) {
+	var events []models.MatchEvent
+	for _, g := range input.Goals {
+		player := players[g.PlayerID]
+		playerID, teamID := player.ID, player.TeamID
+		events = append(events, models.MatchEvent{
+			Type:            g.goalKind().EventType(),
+			Minute:          g.Minute,
+			TeamID:          &teamID,
+			PlayerID:        &playerID,
+			RelatedPlayerID: g.AssistPlayerID,
+		})
+	}
+
+	booked := make(map[uint]bool)
+	for _, e := range input.Events {
+		switch {
+		case e.Type.IsGoal():
+			return nil, "Goals are submitted in goals, not as events"
+		case e.Type == models.EventMissedPenalty, e.Type == models.EventYellowCard, e.Type == models.EventSecondYellow,
+			e.Type == models.EventRedCard, e.Type == models.EventSubstitution:
+			if e.PlayerID == nil {
+				return nil, "A " + string(e.Type) + " event needs a player_id"
+			}
+		case e.Type == models.EventVAR, e.Type == models.EventOther:
+		default:
+			return nil, "Invalid event type. Must be one of: missed_penalty, yellow_card, second_yellow, red_card, substitution, var, other"
+		}
+
+		event := models.MatchEvent{
+			Type:        e.Type,
+			Minute:      e.Minute,
+			Description: e.Description,
+		}
+
+		if e.PlayerID != nil {
+			player := players[*e.PlayerID]
+			playerID, teamID := player.ID, player.TeamID
+			event.PlayerID = &playerID
+			event.TeamID = &teamID
+		} else if e.TeamID != nil {
+			if *e.TeamID != match.HomeTeamID && *e.TeamID != match.AwayTeamID {
+				return nil, "Event team must be one of the two teams in this match"
+			}
+			teamID := *e.TeamID
+			event.TeamID = &teamID
+		}
+
+		if e.Type == models.EventSubstitution {
+			if e.RelatedPlayerID == nil || *e.RelatedPlayerID == *e.PlayerID {
+				return nil, "A substitution needs a different related_player_id for the player going off"
+			}
+			off := players[*e.RelatedPlayerID]
+			if off.TeamID != *event.TeamID {
+				return nil, "Both players of a substitution must be on the same team"
+			}
+			offID := off.ID
+			event.RelatedPlayerID = &offID
+		} else if e.RelatedPlayerID != nil {
+			return nil, "Only a substitution event has a related_player_id"
+		}
+
+		events = append(events, event)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Minute < events[j].Minute })
+
+	// A second yellow needs an earlier yellow card for the same player
+	for _, e := range events {
+		switch e.Type {
+		case models.EventYellowCard:
+			booked[*e.PlayerID] = true
+		case models.EventSecondYellow:
+			if !booked[*e.PlayerID] {
+				return nil, "A second yellow card needs an earlier yellow card for the same player"
+			}
+		}
+	}
+
+	return events, ""
+}
+
+// buildForfeitResult awards the match to the opponent of the team that did not play.
+// No goals are recorded, so the walkover does not count in player scoring.
+func buildForfeitResult(c *gin.Context, match models.Match, input MatchResultInput) (models.MatchResult, bool) {
+	forfeit := input.Forfeit
+	if len(input.Goals) > 0 || len(input.Events) > 0 || input.ExtraTime != nil || len(input.Shootout) > 0 {
+		utils.ValidationErrorResponse(c, "A forfeit cannot have goals, events, extra time or a penalty shootout")
+		return models.MatchResult{}, false
+	}
+	if forfeit.TeamID != match.HomeTeamID && forfeit.TeamID != match.AwayTeamID {
+		utils.ValidationErrorResponse(c, "The forfeiting team must be one of the two teams in this match")
+		return models.MatchResult{}, false
+	}
+
+	awarded := forfeit.AwardedScore
+	if awarded == 0 {
+		awarded = defaultForfeitScore
+	}
+
+	teamID := forfeit.TeamID
+	result := models.MatchResult{
+		MatchID:          match.ID,
+		ResultType:       models.ResultTypeForfeit,
+		ForfeitingTeamID: &teamID,
+		ForfeitReason:    forfeit.Reason,
+	}
+	if teamID == match.HomeTeamID {
+		result.AwayScore = awarded
+	} else {
+		result.HomeScore = awarded
+	}
+	return result, true
 }
 
 // SubmitMatchResult godoc
@@ -38,34 +438,77 @@ func SubmitMatchResult(c *gin.Context) {
 		return
 	}
 
+	// A played result completes the match and a forfeit forfeits it; resubmitting corrects a decided
+	// match and may switch it between the two
+	status := models.MatchStatusCompleted
+	if input.Forfeit != nil {
+		status = models.MatchStatusForfeited
+	}
+	if !match.Status.IsDecided() && !match.Status.CanTransitionTo(status) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Cannot submit a result for a "+string(match.Status)+" match")
+		return
+	}
+
+	// Group results are frozen once they have been used to seed the knockout stage
+	if match.GroupID != nil && match.SeasonID != nil && seasonKnockoutSeeded(config.DB, *match.SeasonID) {
+		utils.ErrorResponse(c, http.StatusConflict, "The group stage is closed: the knockout stage has already been seeded")
+		return
+	}
+
 	// Check if result already exists — update if so
 	var existingResult models.MatchResult
 	resultExists := config.DB.Where("match_id = ?", match.ID).First(&existingResult).Error == nil
 
-	// Validate goals: each player must belong to one of the two teams
-	// and goal count must match scores
-	homeGoalCount := 0
-	awayGoalCount := 0
+	length := matchLength(config.DB, match)
 
-	for _, g := range input.Goals {
-		var player models.Player
-		if err := config.DB.First(&player, g.PlayerID).Error; err != nil {
-			utils.ErrorResponse(c, http.StatusNotFound, "Player not found: player_id "+string(rune(g.PlayerID)))
-			return
+	var candidate models.MatchResult
+	var kicks []models.ShootoutKick
+	var events []models.MatchEvent
+	var ok bool
+	if input.Forfeit != nil {
+		candidate, ok = buildForfeitResult(c, match, input)
+	} else if candidate, kicks, ok = buildPlayedResult(c, match, input, length); ok {
+		events, ok = buildMatchEvents(c, match, input)
+	}
+	if !ok {
+		return
+	}
+
+	// A corrected result of a decided match changes its calendar event
+	resultChanged := resultExists && (calendarScore(&existingResult) != calendarScore(&candidate) ||
+		finalStatusLabel(&existingResult) != finalStatusLabel(&candidate))
+
+	// Everyone named in the result must have been in their team's lineup, and suspended players cannot take part
+	involved := resultPlayerIDs(input)
+	if !rejectPlayersOutsideLineup(c, match, involved) {
+		return
+	}
+	if !rejectSuspendedPlayers(c, match, involved) {
+		return
+	}
+
+	// Minutes played follow from the lineups and the substitutions of the timeline
+	var appearances []models.PlayerAppearance
+	if input.Forfeit == nil {
+		fullTime := length
+		if candidate.ExtraTime {
+			fullTime += models.ExtraTimeLength
 		}
-		if player.TeamID != match.HomeTeamID && player.TeamID != match.AwayTeamID {
-			utils.ValidationErrorResponse(c, "Player does not belong to either team in this match")
+		var lineups []models.MatchLineup
+		config.DB.Preload("Players").Where("match_id = ?", match.ID).Find(&lineups)
+
+		var err error
+		if appearances, err = buildAppearances(match, lineups, events, fullTime); err != nil {
+			utils.ValidationErrorResponse(c, "Invalid substitution: "+err.Error())
 			return
 		}
-		if player.TeamID == match.HomeTeamID {
-			homeGoalCount++
-		} else {
-			awayGoalCount++
-		}
 	}
 
-	if homeGoalCount != input.HomeScore || awayGoalCount != input.AwayScore {
-		utils.ValidationErrorResponse(c, "Number of goals does not match the provided scores")
+	// Knockout ties need a winner to move on to the next round
+	knockout := isKnockoutMatch(match.ID)
+	homeWin, awayWin, _ := resultWinner(&candidate)
+	if knockout && !homeWin && !awayWin {
+		utils.ValidationErrorResponse(c, "A knockout match cannot end in a draw, record extra time or a penalty shootout")
 		return
 	}
 
@@ -74,10 +517,21 @@ func SubmitMatchResult(c *gin.Context) {
 
 	var result models.MatchResult
 	if resultExists {
-		// Delete old goals first
+		// Delete old goals, shootout kicks, events and appearances first
 		tx.Where("match_result_id = ?", existingResult.ID).Delete(&models.Goal{})
-		existingResult.HomeScore = input.HomeScore
-		existingResult.AwayScore = input.AwayScore
+		tx.Where("match_result_id = ?", existingResult.ID).Delete(&models.ShootoutKick{})
+		tx.Where("match_result_id = ?", existingResult.ID).Delete(&models.MatchEvent{})
+		tx.Where("match_result_id = ?", existingResult.ID).Delete(&models.PlayerAppearance{})
+		existingResult.ResultType = candidate.ResultType
+		existingResult.ForfeitingTeamID = candidate.ForfeitingTeamID
+		existingResult.ForfeitReason = candidate.ForfeitReason
+		existingResult.HomeScore = candidate.HomeScore
+		existingResult.AwayScore = candidate.AwayScore
+		existingResult.ExtraTime = candidate.ExtraTime
+		existingResult.HomeExtraTimeScore = candidate.HomeExtraTimeScore
+		existingResult.AwayExtraTimeScore = candidate.AwayExtraTimeScore
+		existingResult.HomePenaltyScore = candidate.HomePenaltyScore
+		existingResult.AwayPenaltyScore = candidate.AwayPenaltyScore
 		if err := tx.Save(&existingResult).Error; err != nil {
 			tx.Rollback()
 			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update match result")
@@ -85,11 +539,7 @@ func SubmitMatchResult(c *gin.Context) {
 		}
 		result = existingResult
 	} else {
-		result = models.MatchResult{
-			MatchID:   match.ID,
-			HomeScore: input.HomeScore,
-			AwayScore: input.AwayScore,
-		}
+		result = candidate
 		if err := tx.Create(&result).Error; err != nil {
 			tx.Rollback()
 			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create match result")
@@ -99,10 +549,14 @@ func SubmitMatchResult(c *gin.Context) {
 
 	// Insert goals
 	for _, g := range input.Goals {
+		scorer, _ := loadMatchPlayer(tx, g.PlayerID, match)
 		goal := models.Goal{
-			MatchResultID: result.ID,
-			PlayerID:      g.PlayerID,
-			Minute:        g.Minute,
+			MatchResultID:  result.ID,
+			PlayerID:       g.PlayerID,
+			TeamID:         scorer.TeamID,
+			Minute:         g.Minute,
+			Kind:           g.goalKind(),
+			AssistPlayerID: g.AssistPlayerID,
 		}
 		if err := tx.Create(&goal).Error; err != nil {
 			tx.Rollback()
@@ -111,18 +565,122 @@ func SubmitMatchResult(c *gin.Context) {
 		}
 	}
 
-	// Mark match as completed
-	match.Status = models.MatchStatusCompleted
-	if err := tx.Save(&match).Error; err != nil {
-		tx.Rollback()
-		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update match status")
-		return
+	// Insert shootout kicks
+	for i := range kicks {
+		kicks[i].MatchResultID = result.ID
+		if err := tx.Create(&kicks[i]).Error; err != nil {
+			tx.Rollback()
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to save shootout kick")
+			return
+		}
 	}
 
+	// Insert the minutes played
+	for i := range appearances {
+		appearances[i].MatchResultID = result.ID
+		if err := tx.Create(&appearances[i]).Error; err != nil {
+			tx.Rollback()
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to save player appearances")
+			return
+		}
+	}
+
+	// Insert the timeline
+	for i := range events {
+		events[i].MatchResultID = result.ID
+		if err := tx.Create(&events[i]).Error; err != nil {
+			tx.Rollback()
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to save match event")
+			return
+		}
+	}
+
+	// Mark match as completed or forfeited
+	if match.Status != status {
+		reason := "Result submitted"
+		if input.Forfeit != nil {
+			reason = "Forfeit: " + input.Forfeit.Reason
+		}
+		if err := changeMatchStatus(tx, &match, status, reason, currentUserID(c)); err != nil {
+			tx.Rollback()
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update match status")
+			return
+		}
+	} else if resultChanged {
+		match.Sequence++
+		if err := tx.Model(&match).Update("sequence", match.Sequence).Error; err != nil {
+			tx.Rollback()
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update match")
+			return
+		}
+	}
+
+	// Knockout matches scheduled by this result
+	var scheduled []models.Match
+
+	if knockout {
+		winnerID := match.HomeTeamID
+		if awayWin {
+			winnerID = match.AwayTeamID
+		}
+		if err := advanceBracketForMatch(tx, match, winnerID); err != nil {
+			tx.Rollback()
+			if errors.Is(err, errNextRoundPlayed) {
+				utils.ErrorResponse(c, http.StatusConflict, "Cannot change the winner: the next round match has already been played")
+				return
+			}
+			var officials *officialConflictError
+			if errors.As(err, &officials) {
+				utils.ErrorResponse(c, http.StatusConflict,
+					fmt.Sprintf("Cannot move the winner to match %d: %s; reassign the official first", officials.MatchID, officials.Conflict))
+				return
+			}
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to advance bracket winner")
+			return
+		}
+		if next, ok := nextRoundMatch(tx, match.ID); ok {
+			scheduled = append(scheduled, next)
+		}
+	}
+
+	// The last group match seeds the knockout stage
+	if match.GroupID != nil && match.SeasonID != nil {
+		bracket, err := seedKnockoutFromGroups(tx, *match.SeasonID)
+		if err != nil {
+			tx.Rollback()
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to seed the knockout stage")
+			return
+		}
+		if bracket != nil {
+			scheduled = append(scheduled, bracketMatches(tx, bracket.ID)...)
+		}
+	}
+
+	// The result stands even when those matches break a rest period; the conflicts are reported
+	// so the matches can be moved with PUT /api/matches/:id
+	conflicts := scheduleConflicts(tx, scheduled)
+
 	tx.Commit()
 
 	// Reload with associations
-	config.DB.Preload("Goals").Preload("Goals.Player").First(&result, result.ID)
+	config.DB.Preload("Goals").Preload("Goals.Player").Preload("Goals.AssistPlayer").
+		Preload("ShootoutKicks", orderShootoutKicks).Preload("ShootoutKicks.Player").
+		Preload("Events", orderMatchEvents).Preload("Events.Player").Preload("Events.RelatedPlayer").
+		First(&result, result.ID)
+
+	if len(conflicts) > 0 {
+		rest := minRestPeriod()
+		c.JSON(http.StatusOK, gin.H{
+			"success": true,
+			"message": fmt.Sprintf("Match result submitted successfully; %d scheduling conflict(s) in the knockout matches it scheduled", len(conflicts)),
+			"data":    result,
+			"schedule_conflicts": ScheduleErrorData{
+				MinRestHours: rest.Hours(),
+				Conflicts:    conflicts,
+			},
+		})
+		return
+	}
 
 	utils.SuccessResponse(c, http.StatusOK, "Match result submitted successfully", result)
 }
@@ -142,6 +700,12 @@ func GetMatchResult(c *gin.Context) {
 	if err := config.DB.
 		Preload("Goals").
 		Preload("Goals.Player").
+		Preload("Goals.AssistPlayer").
+		Preload("ShootoutKicks", orderShootoutKicks).
+		Preload("ShootoutKicks.Player").
+		Preload("Events", orderMatchEvents).
+		Preload("Events.Player").
+		Preload("Events.RelatedPlayer").
 		Where("match_id = ?", matchID).
 		First(&result).Error; err != nil {
 		utils.ErrorResponse(c, http.StatusNotFound, "No result found for this match")
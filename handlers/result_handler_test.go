@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+
+	"ayoindo/models"
+)
+
+func TestResultPlayerIDs(t *testing.T) {
+	id := func(v uint) *uint { return &v }
+
+	tests := []struct {
+		name  string
+		input MatchResultInput
+		want  []uint
+	}{
+		{name: "goalless without events", input: MatchResultInput{}},
+		{
+			name: "scorers and assisters",
+			input: MatchResultInput{Goals: []GoalInput{
+				{PlayerID: 1, Minute: 10, AssistPlayerID: id(2)},
+				{PlayerID: 2, Minute: 20},
+				{PlayerID: 3, Minute: 30, Kind: models.GoalKindOwnGoal},
+			}},
+			want: []uint{1, 2, 3},
+		},
+		{
+			name: "cards and substitutions of a goalless draw",
+			input: MatchResultInput{Events: []MatchEventInput{
+				{Type: models.EventYellowCard, Minute: 12, PlayerID: id(4)},
+				{Type: models.EventSubstitution, Minute: 60, PlayerID: id(5), RelatedPlayerID: id(6)},
+				{Type: models.EventVAR, Minute: 70, TeamID: id(1)},
+				{Type: models.EventRedCard, Minute: 80, PlayerID: id(4)},
+			}},
+			want: []uint{4, 5, 6},
+		},
+		{
+			name: "shootout takers",
+			input: MatchResultInput{
+				Goals:    []GoalInput{{PlayerID: 7, Minute: 5}},
+				Shootout: []ShootoutKickInput{{PlayerID: 8, Scored: true}, {PlayerID: 7}},
+			},
+			want: []uint{7, 8},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resultPlayerIDs(tt.input)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestGoalMinuteError(t *testing.T) {
+	tests := []struct {
+		name      string
+		minute    int
+		length    int
+		extraTime bool
+		wantError bool
+	}{
+		{name: "regulation time", minute: 90, length: 90},
+		{name: "after regulation without extra time", minute: 100, length: 90, wantError: true},
+		{name: "extra time", minute: 100, length: 90, extraTime: true},
+		{name: "last minute of extra time", minute: 120, length: 90, extraTime: true},
+		{name: "shorter match", minute: 75, length: 70, wantError: true},
+		{name: "after extra time of a shorter match", minute: 101, length: 70, extraTime: true, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := goalMinuteError(tt.minute, tt.length, tt.extraTime)
+			if (got != "") != tt.wantError {
+				t.Errorf("goalMinuteError(%d, %d, %v) = %q, want error: %v", tt.minute, tt.length, tt.extraTime, got, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestMergeMatchEvents(t *testing.T) {
+	match := models.Match{ID: 1, HomeTeamID: 1, AwayTeamID: 2}
+	// Players 11 to 13 play for the home team, 21 and 22 for the away team
+	players := map[uint]models.Player{
+		11: {ID: 11, TeamID: 1}, 12: {ID: 12, TeamID: 1}, 13: {ID: 13, TeamID: 1},
+		21: {ID: 21, TeamID: 2}, 22: {ID: 22, TeamID: 2},
+	}
+	event := func(eventType models.MatchEventType, minute int, playerID uint) MatchEventInput {
+		return MatchEventInput{Type: eventType, Minute: minute, PlayerID: uintPtr(playerID)}
+	}
+
+	tests := []struct {
+		name      string
+		input     MatchResultInput
+		wantError string // substring, "" for valid input
+		check     func(t *testing.T, events []models.MatchEvent)
+	}{
+		{
+			name: "goals and events sorted by minute, goals keeping their order",
+			input: MatchResultInput{
+				Goals: []GoalInput{
+					{PlayerID: 11, Minute: 30, AssistPlayerID: uintPtr(12)},
+					{PlayerID: 21, Minute: 30, Kind: models.GoalKindPenalty},
+					{PlayerID: 12, Minute: 5},
+				},
+				Events: []MatchEventInput{
+					event(models.EventYellowCard, 20, 22),
+					{Type: models.EventSubstitution, Minute: 60, PlayerID: uintPtr(13), RelatedPlayerID: uintPtr(11)},
+					{Type: models.EventVAR, Minute: 29, TeamID: uintPtr(2), Description: "Penalty check"},
+				},
+			},
+			check: func(t *testing.T, events []models.MatchEvent) {
+				want := []struct {
+					eventType models.MatchEventType
+					minute    int
+					playerID  uint
+				}{
+					{models.EventGoal, 5, 12},
+					{models.EventYellowCard, 20, 22},
+					{models.EventVAR, 29, 0},
+					{models.EventGoal, 30, 11},
+					{models.EventPenaltyGoal, 30, 21},
+					{models.EventSubstitution, 60, 13},
+				}
+				if len(events) != len(want) {
+					t.Fatalf("got %d events, want %d", len(events), len(want))
+				}
+				for i, w := range want {
+					e := events[i]
+					var playerID uint
+					if e.PlayerID != nil {
+						playerID = *e.PlayerID
+					}
+					if e.Type != w.eventType || e.Minute != w.minute || playerID != w.playerID {
+						t.Errorf("event %d: got %s at %d by %d, want %s at %d by %d", i, e.Type, e.Minute, playerID, w.eventType, w.minute, w.playerID)
+					}
+				}
+				if assist := events[3].RelatedPlayerID; assist == nil || *assist != 12 {
+					t.Errorf("the assist is not kept on the goal event")
+				}
+				if team := events[2].TeamID; team == nil || *team != 2 {
+					t.Errorf("the var event lost its team")
+				}
+				if off := events[5].RelatedPlayerID; off == nil || *off != 11 || *events[5].TeamID != 1 {
+					t.Errorf("the substitution lost the player going off or its team")
+				}
+			},
+		},
+		{
+			name:  "own goal is recorded for the scorer's team",
+			input: MatchResultInput{Goals: []GoalInput{{PlayerID: 21, Minute: 44, Kind: models.GoalKindOwnGoal}}},
+			check: func(t *testing.T, events []models.MatchEvent) {
+				if len(events) != 1 || events[0].Type != models.EventOwnGoal {
+					t.Fatalf("got %+v, want one own_goal event", events)
+				}
+				if *events[0].TeamID != 2 || *events[0].PlayerID != 21 || events[0].RelatedPlayerID != nil {
+					t.Errorf("got team %d, player %d, want the scorer 21 of team 2 without an assist", *events[0].TeamID, *events[0].PlayerID)
+				}
+			},
+		},
+		{
+			name:  "second yellow after a yellow",
+			input: MatchResultInput{Events: []MatchEventInput{event(models.EventSecondYellow, 80, 22), event(models.EventYellowCard, 10, 22)}},
+		},
+		{name: "goal as an event", input: MatchResultInput{Events: []MatchEventInput{event(models.EventGoal, 10, 11)}}, wantError: "submitted in goals"},
+		{name: "own goal as an event", input: MatchResultInput{Events: []MatchEventInput{event(models.EventOwnGoal, 10, 11)}}, wantError: "submitted in goals"},
+		{name: "penalty goal as an event", input: MatchResultInput{Events: []MatchEventInput{event(models.EventPenaltyGoal, 10, 11)}}, wantError: "submitted in goals"},
+		{name: "unknown type", input: MatchResultInput{Events: []MatchEventInput{{Type: "corner", Minute: 10}}}, wantError: "Invalid event type"},
+		{name: "yellow card without a player", input: MatchResultInput{Events: []MatchEventInput{{Type: models.EventYellowCard, Minute: 10}}}, wantError: "yellow_card event needs a player_id"},
+		{name: "second yellow without a player", input: MatchResultInput{Events: []MatchEventInput{{Type: models.EventSecondYellow, Minute: 10}}}, wantError: "second_yellow event needs a player_id"},
+		{name: "red card without a player", input: MatchResultInput{Events: []MatchEventInput{{Type: models.EventRedCard, Minute: 10}}}, wantError: "red_card event needs a player_id"},
+		{name: "missed penalty without a player", input: MatchResultInput{Events: []MatchEventInput{{Type: models.EventMissedPenalty, Minute: 10}}}, wantError: "missed_penalty event needs a player_id"},
+		{name: "substitution without a player", input: MatchResultInput{Events: []MatchEventInput{{Type: models.EventSubstitution, Minute: 10, RelatedPlayerID: uintPtr(11)}}}, wantError: "substitution event needs a player_id"},
+		{name: "var and other need no player", input: MatchResultInput{Events: []MatchEventInput{{Type: models.EventVAR, Minute: 10}, {Type: models.EventOther, Minute: 20}}}},
+		{name: "event for a team outside the match", input: MatchResultInput{Events: []MatchEventInput{{Type: models.EventOther, Minute: 10, TeamID: uintPtr(3)}}}, wantError: "one of the two teams"},
+		{name: "substitution without the player going off", input: MatchResultInput{Events: []MatchEventInput{event(models.EventSubstitution, 60, 13)}}, wantError: "different related_player_id"},
+		{name: "player substituted for themselves", input: MatchResultInput{Events: []MatchEventInput{{Type: models.EventSubstitution, Minute: 60, PlayerID: uintPtr(13), RelatedPlayerID: uintPtr(13)}}}, wantError: "different related_player_id"},
+		{name: "substitution across teams", input: MatchResultInput{Events: []MatchEventInput{{Type: models.EventSubstitution, Minute: 60, PlayerID: uintPtr(13), RelatedPlayerID: uintPtr(21)}}}, wantError: "same team"},
+		{name: "related player on a card", input: MatchResultInput{Events: []MatchEventInput{{Type: models.EventRedCard, Minute: 60, PlayerID: uintPtr(13), RelatedPlayerID: uintPtr(12)}}}, wantError: "Only a substitution"},
+		{name: "second yellow without a yellow", input: MatchResultInput{Events: []MatchEventInput{event(models.EventSecondYellow, 80, 22)}}, wantError: "earlier yellow card"},
+		{name: "second yellow before the yellow", input: MatchResultInput{Events: []MatchEventInput{event(models.EventYellowCard, 85, 22), event(models.EventSecondYellow, 80, 22)}}, wantError: "earlier yellow card"},
+		{name: "second yellow after another player's yellow", input: MatchResultInput{Events: []MatchEventInput{event(models.EventYellowCard, 10, 21), event(models.EventSecondYellow, 80, 22)}}, wantError: "earlier yellow card"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, msg := mergeMatchEvents(match, tt.input, players)
+			if tt.wantError != "" {
+				if !strings.Contains(msg, tt.wantError) {
+					t.Errorf("got error %q, want it to mention %q", msg, tt.wantError)
+				}
+				return
+			}
+			if msg != "" {
+				t.Fatalf("unexpected error: %s", msg)
+			}
+			if tt.check != nil {
+				tt.check(t, events)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"ayoindo/config"
+	"ayoindo/models"
+	"ayoindo/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ScheduledMatch identifies a match in a scheduling conflict
+type ScheduledMatch struct {
+	ID         uint               `json:"id,omitempty"` // missing for a match that is not saved yet
+	HomeTeamID uint               `json:"home_team_id"`
+	AwayTeamID uint               `json:"away_team_id"`
+	MatchDate  string             `json:"match_date"`
+	MatchTime  string             `json:"match_time"`
+	KickoffAt  *time.Time         `json:"kickoff_at"`
+	Status     models.MatchStatus `json:"status"`
+}
+
+// ScheduleConflict is a pair of matches of the same team whose kickoffs are too close together
+type ScheduleConflict struct {
+	TeamID        uint           `json:"team_id"`
+	Match         ScheduledMatch `json:"match"` // the match being scheduled
+	ConflictsWith ScheduledMatch `json:"conflicts_with"`
+	HoursBetween  float64        `json:"hours_between"` // between the two kickoffs
+}
+
+type ScheduleErrorData struct {
+	MinRestHours float64            `json:"min_rest_hours"`
+	Conflicts    []ScheduleConflict `json:"conflicts"`
+}
+
+func scheduledMatch(match models.Match) ScheduledMatch {
+	return ScheduledMatch{
+		ID:         match.ID,
+		HomeTeamID: match.HomeTeamID,
+		AwayTeamID: match.AwayTeamID,
+		MatchDate:  match.MatchDate,
+		MatchTime:  match.MatchTime,
+		KickoffAt:  match.KickoffAt,
+		Status:     match.Status,
+	}
+}
+
+// minRestPeriod is the shortest time between two kickoffs of a team. A team can never play
+// two matches that overlap, so it is at least one match duration.
+func minRestPeriod() time.Duration {
+	rest := config.MinRestPeriod()
+	if duration := config.MatchDuration(); rest < duration {
+		return duration
+	}
+	return rest
+}
+
+// scheduleConflicts checks the matches to be scheduled against each other and against the
+// matches already saved, and returns every pair of matches of a team whose kickoffs are closer
+// than the minimum rest period. Saved matches among the given ones are only compared with their
+// new schedule. Cancelled and postponed matches are left out: their old kickoff no longer holds,
+// and a postponed match is checked again when it is rescheduled.
+func scheduleConflicts(db *gorm.DB, matches []models.Match) []ScheduleConflict {
+	rest := minRestPeriod()
+	conflicts := []ScheduleConflict{}
+	if len(matches) == 0 {
+		return conflicts
+	}
+
+	check := func(match, other models.Match) {
+		start, errA := matchKickoff(match)
+		otherStart, errB := matchKickoff(other)
+		if errA != nil || errB != nil {
+			return
+		}
+		gap := start.Sub(otherStart)
+		if gap < 0 {
+			gap = -gap
+		}
+		if gap >= rest {
+			return
+		}
+		for _, teamID := range []uint{match.HomeTeamID, match.AwayTeamID} {
+			if teamID == other.HomeTeamID || teamID == other.AwayTeamID {
+				conflicts = append(conflicts, ScheduleConflict{
+					TeamID:        teamID,
+					Match:         scheduledMatch(match),
+					ConflictsWith: scheduledMatch(other),
+					HoursBetween:  math.Round(gap.Hours()*100) / 100,
+				})
+			}
+		}
+	}
+
+	teamIDs := make([]uint, 0, 2*len(matches))
+	excludeIDs := []uint{0}
+	for i, match := range matches {
+		teamIDs = append(teamIDs, match.HomeTeamID, match.AwayTeamID)
+		if match.ID != 0 {
+			excludeIDs = append(excludeIDs, match.ID)
+		}
+		for _, other := range matches[:i] {
+			check(match, other)
+		}
+	}
+
+	var existing []models.Match
+	db.Where("(home_team_id IN ? OR away_team_id IN ?) AND id NOT IN ? AND status NOT IN ?",
+		teamIDs, teamIDs, excludeIDs, []models.MatchStatus{models.MatchStatusCancelled, models.MatchStatusPostponed}).
+		Order("kickoff_at ASC, id ASC").
+		Find(&existing)
+	for _, match := range matches {
+		for _, other := range existing {
+			check(match, other)
+		}
+	}
+	return conflicts
+}
+
+// rejectScheduleConflicts writes a 409 listing every conflict and returns false when a team
+// would not get its minimum rest between the given matches and its other matches
+func rejectScheduleConflicts(c *gin.Context, db *gorm.DB, matches []models.Match) bool {
+	conflicts := scheduleConflicts(db, matches)
+	if len(conflicts) == 0 {
+		return true
+	}
+	rest := minRestPeriod()
+	utils.ErrorResponseWithData(c, http.StatusConflict,
+		fmt.Sprintf("Teams need at least %g hours between kickoffs; %d scheduling conflict(s) found", rest.Hours(), len(conflicts)),
+		ScheduleErrorData{MinRestHours: rest.Hours(), Conflicts: conflicts})
+	return false
+}
@@ -0,0 +1,264 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"ayoindo/config"
+	"ayoindo/models"
+	"ayoindo/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SeasonInput struct {
+	Name      string `json:"name" binding:"required,min=2,max=100"`
+	StartDate string `json:"start_date" binding:"required,datetime=2006-01-02"` // YYYY-MM-DD
+	EndDate   string `json:"end_date" binding:"required,datetime=2006-01-02"`   // YYYY-MM-DD
+	TeamIDs   []uint `json:"team_ids"`
+
+	// Standings configuration — omitted fields keep their current value
+	PointsForWin  *int                `json:"points_for_win" binding:"omitempty,min=0,max=10"`
+	PointsForDraw *int                `json:"points_for_draw" binding:"omitempty,min=0,max=10"`
+	TieBreakers   []models.TieBreaker `json:"tie_breakers"`
+}
+
+func isValidTieBreaker(tb models.TieBreaker) bool {
+	switch tb {
+	case models.TieBreakerGoalDifference, models.TieBreakerGoalsFor, models.TieBreakerHeadToHead:
+		return true
+	}
+	return false
+}
+
+// applySeasonStandingsConfig copies the optional standings settings onto the season
+func applySeasonStandingsConfig(c *gin.Context, season *models.Season, input SeasonInput) bool {
+	if input.PointsForWin != nil {
+		season.PointsForWin = *input.PointsForWin
+	}
+	if input.PointsForDraw != nil {
+		season.PointsForDraw = *input.PointsForDraw
+	}
+	if input.TieBreakers != nil {
+		if len(input.TieBreakers) == 0 {
+			utils.ValidationErrorResponse(c, "tie_breakers must not be empty; omit it to keep the current order")
+			return false
+		}
+		seen := make(map[models.TieBreaker]bool)
+		names := make([]string, 0, len(input.TieBreakers))
+		for _, tb := range input.TieBreakers {
+			if !isValidTieBreaker(tb) {
+				utils.ValidationErrorResponse(c, "Invalid tie breaker. Must be one of: goal_difference, goals_for, head_to_head")
+				return false
+			}
+			if seen[tb] {
+				utils.ValidationErrorResponse(c, "Tie breakers must not be repeated")
+				return false
+			}
+			seen[tb] = true
+			names = append(names, string(tb))
+		}
+		season.TieBreakers = strings.Join(names, ",")
+	}
+	if season.PointsForDraw > season.PointsForWin {
+		utils.ValidationErrorResponse(c, "Points for a draw cannot exceed points for a win")
+		return false
+	}
+	return true
+}
+
+// seasonHasTeam reports whether the team is registered as a participant of the season
+func seasonHasTeam(seasonID, teamID uint) bool {
+	var count int64
+	config.DB.Table("season_teams").
+		Where("season_id = ? AND team_id = ?", seasonID, teamID).
+		Count(&count)
+	return count > 0
+}
+
+// loadSeasonTeams validates that every team ID exists and returns the teams
+func loadSeasonTeams(teamIDs []uint) ([]models.Team, bool) {
+	teams := []models.Team{}
+	if len(teamIDs) == 0 {
+		return teams, true
+	}
+	config.DB.Where("id IN ?", teamIDs).Find(&teams)
+
+	unique := make(map[uint]bool)
+	for _, id := range teamIDs {
+		unique[id] = true
+	}
+	return teams, len(teams) == len(unique)
+}
+
+// GetSeasonsByCompetition godoc
+// GET /api/competitions/:id/seasons
+func GetSeasonsByCompetition(c *gin.Context) {
+	competitionID := c.Param("id")
+	var competition models.Competition
+	if err := config.DB.First(&competition, competitionID).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Competition not found")
+		return
+	}
+
+	var seasons []models.Season
+	config.DB.Where("competition_id = ?", competition.ID).Order("start_date ASC").Find(&seasons)
+
+	utils.SuccessResponse(c, http.StatusOK, "Seasons retrieved successfully", seasons)
+}
+
+// CreateSeason godoc
+// POST /api/competitions/:id/seasons
+func CreateSeason(c *gin.Context) {
+	competitionID := c.Param("id")
+	var competition models.Competition
+	if err := config.DB.First(&competition, competitionID).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Competition not found")
+		return
+	}
+
+	var input SeasonInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	// Dates are YYYY-MM-DD so string comparison follows chronological order
+	if input.EndDate < input.StartDate {
+		utils.ValidationErrorResponse(c, "End date cannot be before start date")
+		return
+	}
+
+	teams, ok := loadSeasonTeams(input.TeamIDs)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusNotFound, "One or more teams not found")
+		return
+	}
+
+	season := models.Season{
+		CompetitionID: competition.ID,
+		Name:          input.Name,
+		StartDate:     input.StartDate,
+		EndDate:       input.EndDate,
+		Teams:         teams,
+		PointsForWin:  3,
+		PointsForDraw: 1,
+		TieBreakers:   models.DefaultTieBreakers,
+	}
+	if !applySeasonStandingsConfig(c, &season, input) {
+		return
+	}
+
+	if err := config.DB.Create(&season).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create season")
+		return
+	}
+
+	// GORM replaces zero values with the column default on insert, so write points explicitly
+	config.DB.Model(&season).Updates(map[string]interface{}{
+		"points_for_win":  season.PointsForWin,
+		"points_for_draw": season.PointsForDraw,
+	})
+
+	config.DB.Preload("Competition").Preload("Teams").First(&season, season.ID)
+	utils.SuccessResponse(c, http.StatusCreated, "Season created successfully", season)
+}
+
+// GetSeasonByID godoc
+// GET /api/seasons/:id
+func GetSeasonByID(c *gin.Context) {
+	id := c.Param("id")
+	var season models.Season
+
+	if err := config.DB.Preload("Competition").Preload("Teams").First(&season, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Season not found")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Season retrieved successfully", season)
+}
+
+// UpdateSeason godoc
+// PUT /api/seasons/:id — team_ids replaces the participant list
+func UpdateSeason(c *gin.Context) {
+	id := c.Param("id")
+	var season models.Season
+
+	if err := config.DB.First(&season, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Season not found")
+		return
+	}
+
+	var input SeasonInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	if input.EndDate < input.StartDate {
+		utils.ValidationErrorResponse(c, "End date cannot be before start date")
+		return
+	}
+
+	teams, ok := loadSeasonTeams(input.TeamIDs)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusNotFound, "One or more teams not found")
+		return
+	}
+
+	renamed := season.Name != input.Name
+
+	season.Name = input.Name
+	season.StartDate = input.StartDate
+	season.EndDate = input.EndDate
+	if !applySeasonStandingsConfig(c, &season, input) {
+		return
+	}
+
+	tx := config.DB.Begin()
+
+	if err := tx.Save(&season).Error; err != nil {
+		tx.Rollback()
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update season")
+		return
+	}
+
+	if err := tx.Model(&season).Association("Teams").Replace(teams); err != nil {
+		tx.Rollback()
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update season teams")
+		return
+	}
+
+	// Calendar events show the season's name
+	if renamed {
+		if err := bumpCalendarSequence(tx, "season_id = ?", season.ID); err != nil {
+			tx.Rollback()
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update season")
+			return
+		}
+	}
+
+	tx.Commit()
+
+	config.DB.Preload("Competition").Preload("Teams").First(&season, season.ID)
+	utils.SuccessResponse(c, http.StatusOK, "Season updated successfully", season)
+}
+
+// DeleteSeason godoc
+// DELETE /api/seasons/:id — soft delete
+func DeleteSeason(c *gin.Context) {
+	id := c.Param("id")
+	var season models.Season
+
+	if err := config.DB.First(&season, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Season not found")
+		return
+	}
+
+	if err := config.DB.Delete(&season).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete season")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Season deleted successfully", nil)
+}
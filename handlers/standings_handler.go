@@ -0,0 +1,381 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"ayoindo/config"
+	"ayoindo/models"
+	"ayoindo/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type StandingRow struct {
+	Position       int    `json:"position"`
+	TeamID         uint   `json:"team_id"`
+	TeamName       string `json:"team_name"`
+	Played         int    `json:"played"`
+	Won            int    `json:"won"`
+	Drawn          int    `json:"drawn"`
+	Lost           int    `json:"lost"`
+	GoalsFor       int    `json:"goals_for"`
+	GoalsAgainst   int    `json:"goals_against"`
+	GoalDifference int    `json:"goal_difference"`
+	Points         int    `json:"points"`
+}
+
+type StandingsData struct {
+	SeasonID      uint          `json:"season_id"`
+	AsOf          string        `json:"as_of,omitempty"`    // YYYY-MM-DD, matches played on or before this date
+	Matchday      int           `json:"matchday,omitempty"` // matches of matchdays 1..Matchday
+	PointsForWin  int           `json:"points_for_win"`
+	PointsForDraw int           `json:"points_for_draw"`
+	TieBreakers   []string      `json:"tie_breakers"`
+	Table         []StandingRow `json:"table"`
+}
+
+// standingsRules holds the scoring and ordering configuration of a table
+type standingsRules struct {
+	PointsForWin  int
+	PointsForDraw int
+	TieBreakers   []models.TieBreaker
+}
+
+func seasonStandingsRules(season models.Season) standingsRules {
+	rules := standingsRules{
+		PointsForWin:  season.PointsForWin,
+		PointsForDraw: season.PointsForDraw,
+	}
+	tieBreakers := season.TieBreakers
+	if tieBreakers == "" {
+		tieBreakers = models.DefaultTieBreakers
+	}
+	for _, tb := range strings.Split(tieBreakers, ",") {
+		rules.TieBreakers = append(rules.TieBreakers, models.TieBreaker(strings.TrimSpace(tb)))
+	}
+	return rules
+}
+
+type PositionPoint struct {
+	Label    string `json:"label"` // date (YYYY-MM-DD) or matchday number
+	Position int    `json:"position"`
+	Points   int    `json:"points"`
+}
+
+type TeamPositionSeries struct {
+	TeamID   uint            `json:"team_id"`
+	TeamName string          `json:"team_name"`
+	Series   []PositionPoint `json:"series"`
+}
+
+// seasonCompletedMatches loads the completed league and group matches of a season with their results,
+// in chronological order. Knockout bracket matches do not count towards a table.
+func seasonCompletedMatches(db *gorm.DB, seasonID uint) []models.Match {
+	var matches []models.Match
+	completedMatchesQuery(db).
+		Preload("HomeTeam").
+		Preload("AwayTeam").
+		Preload("MatchResult").
+		Where("matches.season_id = ?", seasonID).
+		Where("matches.id NOT IN (?)", db.Model(&models.BracketSlot{}).Select("match_id").Where("match_id IS NOT NULL")).
+		Order(chronologicalOrder).
+		Find(&matches)
+	return matches
+}
+
+// kickoffDate is the date (YYYY-MM-DD) of a match's kickoff in the given time zone. Matches of one
+// season may be played in several zones, so tables are cut off by one zone for the whole season.
+func kickoffDate(match models.Match, loc *time.Location) string {
+	if match.KickoffAt == nil {
+		return match.MatchDate
+	}
+	return match.KickoffAt.In(loc).Format("2006-01-02")
+}
+
+// matchesUntilDate keeps the matches kicked off on or before the date (YYYY-MM-DD) in the time zone
+func matchesUntilDate(matches []models.Match, date string, loc *time.Location) []models.Match {
+	var played []models.Match
+	for _, m := range matches {
+		if kickoffDate(m, loc) <= date {
+			played = append(played, m)
+		}
+	}
+	return played
+}
+
+// matchesUntilMatchday keeps the matches scheduled on matchdays 1 to matchday. Bracket matches store
+// their round in Matchday, so callers pass matches from seasonCompletedMatches only.
+func matchesUntilMatchday(matches []models.Match, matchday int) []models.Match {
+	var played []models.Match
+	for _, m := range matches {
+		if m.Matchday >= 1 && m.Matchday <= matchday {
+			played = append(played, m)
+		}
+	}
+	return played
+}
+
+// headToHeadPoints returns the points each team collected in matches played among the given teams only
+func headToHeadPoints(teamIDs []uint, matches []models.Match, rules standingsRules) map[uint]int {
+	inGroup := make(map[uint]bool)
+	for _, id := range teamIDs {
+		inGroup[id] = true
+	}
+
+	points := make(map[uint]int)
+	for _, m := range matches {
+		if m.MatchResult == nil || !inGroup[m.HomeTeamID] || !inGroup[m.AwayTeamID] {
+			continue
+		}
+		switch {
+		case m.MatchResult.HomeScore > m.MatchResult.AwayScore:
+			points[m.HomeTeamID] += rules.PointsForWin
+		case m.MatchResult.AwayScore > m.MatchResult.HomeScore:
+			points[m.AwayTeamID] += rules.PointsForWin
+		default:
+			points[m.HomeTeamID] += rules.PointsForDraw
+			points[m.AwayTeamID] += rules.PointsForDraw
+		}
+	}
+	return points
+}
+
+// computeStandings aggregates completed matches into an ordered league table.
+// Every team in teams gets a row even if it has not played yet.
+func computeStandings(teams []models.Team, matches []models.Match, rules standingsRules) []StandingRow {
+	rows := make(map[uint]*StandingRow)
+	for _, t := range teams {
+		rows[t.ID] = &StandingRow{TeamID: t.ID, TeamName: t.Name}
+	}
+
+	rowFor := func(team *models.Team, teamID uint) *StandingRow {
+		if _, exists := rows[teamID]; !exists {
+			name := ""
+			if team != nil {
+				name = team.Name
+			}
+			rows[teamID] = &StandingRow{TeamID: teamID, TeamName: name}
+		}
+		return rows[teamID]
+	}
+
+	for _, m := range matches {
+		if m.MatchResult == nil {
+			continue
+		}
+		home := rowFor(m.HomeTeam, m.HomeTeamID)
+		away := rowFor(m.AwayTeam, m.AwayTeamID)
+		homeScore, awayScore := m.MatchResult.HomeScore, m.MatchResult.AwayScore
+
+		home.Played++
+		away.Played++
+		home.GoalsFor += homeScore
+		home.GoalsAgainst += awayScore
+		away.GoalsFor += awayScore
+		away.GoalsAgainst += homeScore
+
+		switch {
+		case homeScore > awayScore:
+			home.Won++
+			away.Lost++
+		case awayScore > homeScore:
+			away.Won++
+			home.Lost++
+		default:
+			home.Drawn++
+			away.Drawn++
+		}
+	}
+
+	table := make([]StandingRow, 0, len(rows))
+	for _, r := range rows {
+		r.GoalDifference = r.GoalsFor - r.GoalsAgainst
+		r.Points = r.Won*rules.PointsForWin + r.Drawn*rules.PointsForDraw
+		table = append(table, *r)
+	}
+
+	// Primary order: points, then name so that the result is deterministic
+	sort.SliceStable(table, func(i, j int) bool {
+		if table[i].Points != table[j].Points {
+			return table[i].Points > table[j].Points
+		}
+		return table[i].TeamName < table[j].TeamName
+	})
+
+	// Resolve each block of teams level on points with the configured tie breakers
+	for start := 0; start < len(table); {
+		end := start + 1
+		for end < len(table) && table[end].Points == table[start].Points {
+			end++
+		}
+		if end-start > 1 {
+			block := table[start:end]
+			var tied []uint
+			for _, r := range block {
+				tied = append(tied, r.TeamID)
+			}
+			h2h := headToHeadPoints(tied, matches, rules)
+
+			sort.SliceStable(block, func(i, j int) bool {
+				a, b := block[i], block[j]
+				for _, tb := range rules.TieBreakers {
+					switch tb {
+					case models.TieBreakerGoalDifference:
+						if a.GoalDifference != b.GoalDifference {
+							return a.GoalDifference > b.GoalDifference
+						}
+					case models.TieBreakerGoalsFor:
+						if a.GoalsFor != b.GoalsFor {
+							return a.GoalsFor > b.GoalsFor
+						}
+					case models.TieBreakerHeadToHead:
+						if h2h[a.TeamID] != h2h[b.TeamID] {
+							return h2h[a.TeamID] > h2h[b.TeamID]
+						}
+					}
+				}
+				return false
+			})
+		}
+		start = end
+	}
+
+	for i := range table {
+		table[i].Position = i + 1
+	}
+	return table
+}
+
+// GetSeasonStandings godoc
+// GET /api/seasons/:id/standings
+func GetSeasonStandings(c *gin.Context) {
+	id := c.Param("id")
+	var season models.Season
+
+	if err := config.DB.Preload("Teams").First(&season, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Season not found")
+		return
+	}
+
+	rules := seasonStandingsRules(season)
+	matches := seasonCompletedMatches(config.DB, season.ID)
+
+	// Optional point in time: a date or a matchday
+	asOf := c.Query("as_of")
+	matchday := 0
+	if asOf != "" {
+		if _, err := time.Parse("2006-01-02", asOf); err != nil {
+			utils.ValidationErrorResponse(c, "as_of must be a date in YYYY-MM-DD format")
+			return
+		}
+		matches = matchesUntilDate(matches, asOf, kickoffLocation(config.DB, nil, &season.ID))
+	}
+	if md := c.Query("matchday"); md != "" {
+		n, err := strconv.Atoi(md)
+		if err != nil || n < 1 {
+			utils.ValidationErrorResponse(c, "matchday must be a positive number")
+			return
+		}
+		matchday = n
+		matches = matchesUntilMatchday(matches, matchday)
+	}
+
+	tieBreakers := make([]string, 0, len(rules.TieBreakers))
+	for _, tb := range rules.TieBreakers {
+		tieBreakers = append(tieBreakers, string(tb))
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Standings retrieved successfully", StandingsData{
+		SeasonID:      season.ID,
+		AsOf:          asOf,
+		Matchday:      matchday,
+		PointsForWin:  rules.PointsForWin,
+		PointsForDraw: rules.PointsForDraw,
+		TieBreakers:   tieBreakers,
+		Table:         computeStandings(season.Teams, matches, rules),
+	})
+}
+
+// GetSeasonStandingsHistory godoc
+// GET /api/seasons/:id/standings/history — league position of every team after each match date or matchday
+func GetSeasonStandingsHistory(c *gin.Context) {
+	id := c.Param("id")
+	var season models.Season
+
+	if err := config.DB.Preload("Teams").First(&season, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Season not found")
+		return
+	}
+
+	by := c.DefaultQuery("by", "date")
+	if by != "date" && by != "matchday" {
+		utils.ValidationErrorResponse(c, "by must be one of: date, matchday")
+		return
+	}
+
+	rules := seasonStandingsRules(season)
+	matches := seasonCompletedMatches(config.DB, season.ID)
+
+	// Snapshot points: every distinct match date, or every matchday, in chronological order
+	var labels []string
+	var snapshots [][]models.Match
+	if by == "date" {
+		// Matches come in kickoff order, so their dates in one time zone only go forward
+		loc := kickoffLocation(config.DB, nil, &season.ID)
+		for _, m := range matches {
+			if date := kickoffDate(m, loc); len(labels) == 0 || labels[len(labels)-1] != date {
+				labels = append(labels, date)
+				snapshots = append(snapshots, matchesUntilDate(matches, date, loc))
+			}
+		}
+	} else {
+		lastMatchday := 0
+		for _, m := range matches {
+			if m.Matchday > lastMatchday {
+				lastMatchday = m.Matchday
+			}
+		}
+		for md := 1; md <= lastMatchday; md++ {
+			labels = append(labels, strconv.Itoa(md))
+			snapshots = append(snapshots, matchesUntilMatchday(matches, md))
+		}
+	}
+
+	seriesByTeam := make(map[uint]*TeamPositionSeries)
+	var order []uint
+	for i, played := range snapshots {
+		for _, row := range computeStandings(season.Teams, played, rules) {
+			if _, exists := seriesByTeam[row.TeamID]; !exists {
+				seriesByTeam[row.TeamID] = &TeamPositionSeries{TeamID: row.TeamID, TeamName: row.TeamName}
+				order = append(order, row.TeamID)
+			}
+			seriesByTeam[row.TeamID].Series = append(seriesByTeam[row.TeamID].Series, PositionPoint{
+				Label:    labels[i],
+				Position: row.Position,
+				Points:   row.Points,
+			})
+		}
+	}
+
+	teamFilter := c.Query("team_id")
+	series := make([]TeamPositionSeries, 0, len(order))
+	for _, teamID := range order {
+		if teamFilter != "" && teamFilter != strconv.FormatUint(uint64(teamID), 10) {
+			continue
+		}
+		series = append(series, *seriesByTeam[teamID])
+	}
+
+	// Latest table order first
+	sort.SliceStable(series, func(i, j int) bool {
+		a, b := series[i].Series, series[j].Series
+		return a[len(a)-1].Position < b[len(b)-1].Position
+	})
+
+	utils.SuccessResponse(c, http.StatusOK, "Standings history retrieved successfully", series)
+}
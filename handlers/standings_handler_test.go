@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"ayoindo/models"
+)
+
+// played is a completed match between two teams with the given score
+func played(home, away uint, homeScore, awayScore int) models.Match {
+	return models.Match{
+		HomeTeamID:  home,
+		AwayTeamID:  away,
+		Status:      models.MatchStatusCompleted,
+		MatchResult: &models.MatchResult{HomeScore: homeScore, AwayScore: awayScore},
+	}
+}
+
+func tableOrder(table []StandingRow) []uint {
+	order := make([]uint, len(table))
+	for i, r := range table {
+		order[i] = r.TeamID
+	}
+	return order
+}
+
+func TestComputeStandingsTieBreakers(t *testing.T) {
+	teams := []models.Team{{ID: 1, Name: "Alpha"}, {ID: 2, Name: "Bravo"}, {ID: 3, Name: "Charlie"}, {ID: 4, Name: "Delta"}}
+
+	// Alpha and Bravo finish on 6 points. Bravo has the better goal difference,
+	// Alpha scored more and won the match between them.
+	matches := []models.Match{
+		played(1, 2, 1, 0),
+		played(1, 3, 6, 4),
+		played(4, 1, 3, 0),
+		played(2, 3, 4, 0),
+		played(2, 4, 2, 0),
+		played(3, 4, 0, 0),
+	}
+
+	tests := []struct {
+		name        string
+		tieBreakers []models.TieBreaker
+		want        []uint
+	}{
+		{
+			name:        "goal difference first",
+			tieBreakers: []models.TieBreaker{models.TieBreakerGoalDifference, models.TieBreakerGoalsFor, models.TieBreakerHeadToHead},
+			want:        []uint{2, 1, 4, 3},
+		},
+		{
+			name:        "goals scored first",
+			tieBreakers: []models.TieBreaker{models.TieBreakerGoalsFor, models.TieBreakerGoalDifference},
+			want:        []uint{1, 2, 4, 3},
+		},
+		{
+			name:        "head to head first",
+			tieBreakers: []models.TieBreaker{models.TieBreakerHeadToHead, models.TieBreakerGoalDifference},
+			want:        []uint{1, 2, 4, 3},
+		},
+		{
+			name: "no tie breakers keeps name order",
+			want: []uint{1, 2, 4, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := standingsRules{PointsForWin: 3, PointsForDraw: 1, TieBreakers: tt.tieBreakers}
+			table := computeStandings(teams, matches, rules)
+
+			got := tableOrder(table)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d rows, want %d", len(got), len(tt.want))
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got order %v, want %v", got, tt.want)
+				}
+			}
+			for i, r := range table {
+				if r.Position != i+1 {
+					t.Errorf("row %d has position %d", i, r.Position)
+				}
+			}
+		})
+	}
+}
+
+func TestComputeStandingsTotals(t *testing.T) {
+	teams := []models.Team{{ID: 1, Name: "Alpha"}, {ID: 2, Name: "Bravo"}, {ID: 3, Name: "Charlie"}}
+	matches := []models.Match{
+		played(1, 2, 2, 2),
+		played(2, 1, 3, 1),
+	}
+	rules := standingsRules{PointsForWin: 2, PointsForDraw: 1}
+
+	table := computeStandings(teams, matches, rules)
+	rows := make(map[uint]StandingRow)
+	for _, r := range table {
+		rows[r.TeamID] = r
+	}
+
+	bravo := rows[2]
+	if bravo.Played != 2 || bravo.Won != 1 || bravo.Drawn != 1 || bravo.Lost != 0 ||
+		bravo.GoalsFor != 5 || bravo.GoalsAgainst != 3 || bravo.GoalDifference != 2 || bravo.Points != 3 {
+		t.Errorf("unexpected row for Bravo: %+v", bravo)
+	}
+	if charlie := rows[3]; charlie.Played != 0 || charlie.Points != 0 {
+		t.Errorf("a team without matches should have an empty row, got %+v", charlie)
+	}
+	if got := tableOrder(table); got[0] != 2 || got[1] != 1 || got[2] != 3 {
+		t.Errorf("got order %v, want [2 1 3]", got)
+	}
+}
+
+func TestHeadToHeadPointsIgnoresOtherOpponents(t *testing.T) {
+	matches := []models.Match{
+		played(1, 2, 0, 1),
+		played(1, 3, 4, 0),
+		played(2, 3, 0, 0),
+	}
+	rules := standingsRules{PointsForWin: 3, PointsForDraw: 1}
+
+	points := headToHeadPoints([]uint{1, 2}, matches, rules)
+	if points[1] != 0 || points[2] != 3 {
+		t.Errorf("got %v, want team 1: 0, team 2: 3", points)
+	}
+}
+
+func TestMatchesUntilDateUsesKickoffDate(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	kickoff := func(s string) *time.Time {
+		k, _ := time.Parse(time.RFC3339, s)
+		return &k
+	}
+
+	// Kicked off at 00:30 on 16 March in Jakarta, still 15 March in UTC; the stored date is UTC
+	late := played(1, 2, 1, 0)
+	late.MatchDate, late.KickoffAt = "2025-03-15", kickoff("2025-03-15T17:30:00Z")
+	early := played(3, 4, 0, 0)
+	early.MatchDate, early.KickoffAt = "2025-03-15", kickoff("2025-03-15T12:00:00Z")
+	unscheduled := played(1, 3, 2, 2)
+	unscheduled.MatchDate = "2025-03-14"
+
+	if got := kickoffDate(late, jakarta); got != "2025-03-16" {
+		t.Errorf("kickoffDate = %s, want 2025-03-16", got)
+	}
+	if got := kickoffDate(unscheduled, jakarta); got != "2025-03-14" {
+		t.Errorf("kickoffDate without a kickoff = %s, want the match date 2025-03-14", got)
+	}
+
+	matches := []models.Match{unscheduled, early, late}
+	tests := []struct {
+		date string
+		want int
+	}{
+		{date: "2025-03-13", want: 0},
+		{date: "2025-03-14", want: 1},
+		{date: "2025-03-15", want: 2},
+		{date: "2025-03-16", want: 3},
+	}
+	for _, tt := range tests {
+		if got := matchesUntilDate(matches, tt.date, jakarta); len(got) != tt.want {
+			t.Errorf("as of %s: got %d matches, want %d", tt.date, len(got), tt.want)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"ayoindo/config"
+	"ayoindo/models"
+	"ayoindo/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Why a player is suspended
+const (
+	SuspensionReasonYellowCards = "yellow_cards"
+	SuspensionReasonRedCard     = "red_card"
+)
+
+type Suspension struct {
+	PlayerID         uint   `json:"player_id"`
+	PlayerName       string `json:"player_name"`
+	TeamID           uint   `json:"team_id"`
+	CompetitionID    uint   `json:"competition_id"`
+	CompetitionName  string `json:"competition_name"`
+	Reason           string `json:"reason"`            // the card that caused the latest ban
+	MatchesRemaining int    `json:"matches_remaining"` // matches of the team still to sit out
+	YellowCards      int    `json:"yellow_cards"`      // counted towards accumulation in the competition
+	RedCards         int    `json:"red_cards"`         // red cards and second yellows in the competition
+}
+
+// disciplineRecord is the card tally of a player in one competition
+type disciplineRecord struct {
+	TeamID           uint
+	YellowCards      int
+	RedCards         int
+	MatchesRemaining int
+	Reason           string
+}
+
+// bookingRow is a card event together with the match it was given in
+type bookingRow struct {
+	MatchID  uint
+	PlayerID uint
+	TeamID   uint
+	Type     models.MatchEventType
+}
+
+// competitionDiscipline replays the bookings of a competition's completed matches in kickoff order
+// and returns the record of every booked player. A ban starts with the team's next match, and every
+// completed match of the team serves one match of it. With before set, only the matches kicked off
+// before that match are replayed, which gives the suspensions that apply to it.
+func competitionDiscipline(db *gorm.DB, competition models.Competition, before *models.Match) map[uint]*disciplineRecord {
+	query := completedMatchesQuery(db).
+		Joins("JOIN seasons ON seasons.id = matches.season_id").
+		Where("seasons.competition_id = ?", competition.ID)
+	if before != nil {
+		query = playedUpTo(query, *before).Where("matches.id <> ?", before.ID)
+	}
+
+	var matches []models.Match
+	query.Order(chronologicalOrder).Find(&matches)
+
+	if len(matches) == 0 {
+		return map[uint]*disciplineRecord{}
+	}
+
+	matchIDs := make([]uint, len(matches))
+	for i, m := range matches {
+		matchIDs[i] = m.ID
+	}
+
+	var bookings []bookingRow
+	db.Table("match_events").
+		Select("match_results.match_id, match_events.player_id, match_events.team_id, match_events.type").
+		Joins("JOIN match_results ON match_results.id = match_events.match_result_id AND match_results.deleted_at IS NULL").
+		Where("match_events.deleted_at IS NULL AND match_results.match_id IN ? AND match_events.type IN ?", matchIDs,
+			[]models.MatchEventType{models.EventYellowCard, models.EventSecondYellow, models.EventRedCard}).
+		Order("match_events.minute ASC, match_events.id ASC").
+		Scan(&bookings)
+
+	return replayDiscipline(competition, matches, bookings)
+}
+
+// replayDiscipline applies the competition's card rules to the bookings of the matches, given in
+// kickoff order with the bookings of each match in minute order
+func replayDiscipline(competition models.Competition, matches []models.Match, bookings []bookingRow) map[uint]*disciplineRecord {
+	bookingsByMatch := make(map[uint][]bookingRow)
+	for _, b := range bookings {
+		bookingsByMatch[b.MatchID] = append(bookingsByMatch[b.MatchID], b)
+	}
+
+	records := make(map[uint]*disciplineRecord)
+	for _, m := range matches {
+		// Players banned before this match sit it out
+		for _, rec := range records {
+			if rec.MatchesRemaining > 0 && (rec.TeamID == m.HomeTeamID || rec.TeamID == m.AwayTeamID) {
+				rec.MatchesRemaining--
+			}
+		}
+
+		yellows := make(map[uint]int)
+		sentOff := make(map[uint]bool)
+		seen := make(map[uint]bool)
+		var booked []uint
+		for _, b := range bookingsByMatch[m.ID] {
+			rec, ok := records[b.PlayerID]
+			if !ok {
+				rec = &disciplineRecord{}
+				records[b.PlayerID] = rec
+			}
+			rec.TeamID = b.TeamID
+			if !seen[b.PlayerID] {
+				seen[b.PlayerID] = true
+				booked = append(booked, b.PlayerID)
+			}
+
+			switch b.Type {
+			case models.EventYellowCard:
+				yellows[b.PlayerID]++
+			case models.EventSecondYellow:
+				// The first yellow is part of the sending off and does not count towards accumulation
+				yellows[b.PlayerID]--
+				sentOff[b.PlayerID] = true
+			case models.EventRedCard:
+				sentOff[b.PlayerID] = true
+			}
+		}
+
+		for _, playerID := range booked {
+			rec := records[playerID]
+			if n := yellows[playerID]; n > 0 {
+				previous := rec.YellowCards
+				rec.YellowCards += n
+				if competition.YellowCardThreshold > 0 {
+					bans := rec.YellowCards/competition.YellowCardThreshold - previous/competition.YellowCardThreshold
+					if bans > 0 {
+						rec.MatchesRemaining += bans * competition.YellowCardBan
+						rec.Reason = SuspensionReasonYellowCards
+					}
+				}
+			}
+			if sentOff[playerID] {
+				rec.RedCards++
+				rec.MatchesRemaining += competition.RedCardBan
+				rec.Reason = SuspensionReasonRedCard
+			}
+		}
+	}
+	return records
+}
+
+// matchDiscipline returns the disciplinary records that apply to a match of a season; it is empty
+// for friendlies that do not belong to a competition
+func matchDiscipline(db *gorm.DB, match models.Match) map[uint]*disciplineRecord {
+	if match.SeasonID == nil {
+		return map[uint]*disciplineRecord{}
+	}
+	var season models.Season
+	if err := db.Preload("Competition").First(&season, *match.SeasonID).Error; err != nil || season.Competition == nil {
+		return map[uint]*disciplineRecord{}
+	}
+	return competitionDiscipline(db, *season.Competition, &match)
+}
+
+// rejectSuspendedPlayers writes an error response and returns false when any of the players
+// is suspended for the match
+func rejectSuspendedPlayers(c *gin.Context, match models.Match, playerIDs []uint) bool {
+	records := matchDiscipline(config.DB, match)
+	for _, id := range playerIDs {
+		if rec, ok := records[id]; ok && rec.MatchesRemaining > 0 {
+			utils.ValidationErrorResponse(c, fmt.Sprintf("Player %d is suspended for this match (%d match(es) remaining)", id, rec.MatchesRemaining))
+			return false
+		}
+	}
+	return true
+}
+
+// GetSuspensions godoc
+// GET /api/suspensions — players currently suspended, optionally for one competition or team
+func GetSuspensions(c *gin.Context) {
+	var competitions []models.Competition
+	query := config.DB.Model(&models.Competition{})
+	if competitionID := c.Query("competition_id"); competitionID != "" {
+		query = query.Where("id = ?", competitionID)
+	}
+	query.Order("id ASC").Find(&competitions)
+
+	teamFilter := c.Query("team_id")
+
+	suspensions := []Suspension{}
+	for _, competition := range competitions {
+		for playerID, rec := range competitionDiscipline(config.DB, competition, nil) {
+			if rec.MatchesRemaining == 0 {
+				continue
+			}
+			if teamFilter != "" && teamFilter != fmt.Sprint(rec.TeamID) {
+				continue
+			}
+			var player models.Player
+			config.DB.Unscoped().First(&player, playerID)
+			suspensions = append(suspensions, Suspension{
+				PlayerID:         playerID,
+				PlayerName:       player.Name,
+				TeamID:           rec.TeamID,
+				CompetitionID:    competition.ID,
+				CompetitionName:  competition.Name,
+				Reason:           rec.Reason,
+				MatchesRemaining: rec.MatchesRemaining,
+				YellowCards:      rec.YellowCards,
+				RedCards:         rec.RedCards,
+			})
+		}
+	}
+
+	sort.Slice(suspensions, func(i, j int) bool {
+		a, b := suspensions[i], suspensions[j]
+		if a.CompetitionID != b.CompetitionID {
+			return a.CompetitionID < b.CompetitionID
+		}
+		if a.TeamID != b.TeamID {
+			return a.TeamID < b.TeamID
+		}
+		return a.PlayerName < b.PlayerName
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Suspensions retrieved successfully",
+		"data":    suspensions,
+		"total":   len(suspensions),
+	})
+}
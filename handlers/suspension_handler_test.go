@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"testing"
+
+	"ayoindo/models"
+)
+
+func TestReplayDiscipline(t *testing.T) {
+	const player = 10
+	yellow := func(matchID uint) bookingRow {
+		return bookingRow{MatchID: matchID, PlayerID: player, TeamID: 1, Type: models.EventYellowCard}
+	}
+	card := func(matchID uint, eventType models.MatchEventType) bookingRow {
+		return bookingRow{MatchID: matchID, PlayerID: player, TeamID: 1, Type: eventType}
+	}
+	standard := models.Competition{YellowCardThreshold: 3, YellowCardBan: 1, RedCardBan: 1}
+
+	// Team 1 plays matches 1 to 4 and 6; match 5 is between teams 2 and 3
+	matches := []models.Match{
+		{ID: 1, HomeTeamID: 1, AwayTeamID: 2},
+		{ID: 2, HomeTeamID: 3, AwayTeamID: 1},
+		{ID: 3, HomeTeamID: 1, AwayTeamID: 3},
+		{ID: 4, HomeTeamID: 2, AwayTeamID: 1},
+		{ID: 5, HomeTeamID: 2, AwayTeamID: 3},
+		{ID: 6, HomeTeamID: 1, AwayTeamID: 2},
+	}
+
+	tests := []struct {
+		name          string
+		competition   models.Competition
+		played        int // number of matches replayed
+		bookings      []bookingRow
+		wantYellows   int
+		wantReds      int
+		wantRemaining int
+		wantReason    string
+	}{
+		{
+			name:        "below the threshold",
+			competition: standard,
+			played:      3,
+			bookings:    []bookingRow{yellow(1), yellow(2)},
+			wantYellows: 2,
+		},
+		{
+			name:          "threshold reached",
+			competition:   standard,
+			played:        3,
+			bookings:      []bookingRow{yellow(1), yellow(2), yellow(3)},
+			wantYellows:   3,
+			wantRemaining: 1,
+			wantReason:    SuspensionReasonYellowCards,
+		},
+		{
+			name:        "ban served by the next match of the team",
+			competition: standard,
+			played:      4,
+			bookings:    []bookingRow{yellow(1), yellow(2), yellow(3)},
+			wantYellows: 3,
+			wantReason:  SuspensionReasonYellowCards,
+		},
+		{
+			name:          "other teams' matches do not serve the ban",
+			competition:   standard,
+			played:        5,
+			bookings:      []bookingRow{yellow(1), yellow(2), yellow(4)},
+			wantYellows:   3,
+			wantRemaining: 1,
+			wantReason:    SuspensionReasonYellowCards,
+		},
+		{
+			name:          "longer accumulation ban",
+			competition:   models.Competition{YellowCardThreshold: 2, YellowCardBan: 2, RedCardBan: 1},
+			played:        2,
+			bookings:      []bookingRow{yellow(1), yellow(2)},
+			wantYellows:   2,
+			wantRemaining: 2,
+			wantReason:    SuspensionReasonYellowCards,
+		},
+		{
+			name:        "no accumulation bans",
+			competition: models.Competition{YellowCardThreshold: 0, RedCardBan: 1},
+			played:      4,
+			bookings:    []bookingRow{yellow(1), yellow(2), yellow(3), yellow(4)},
+			wantYellows: 4,
+		},
+		{
+			name:          "second yellow is a sending off",
+			competition:   standard,
+			played:        1,
+			bookings:      []bookingRow{yellow(1), card(1, models.EventSecondYellow)},
+			wantReds:      1,
+			wantRemaining: 1,
+			wantReason:    SuspensionReasonRedCard,
+		},
+		{
+			name:          "red card ban",
+			competition:   models.Competition{YellowCardThreshold: 3, YellowCardBan: 1, RedCardBan: 3},
+			played:        3,
+			bookings:      []bookingRow{card(1, models.EventRedCard)},
+			wantReds:      1,
+			wantRemaining: 1,
+			wantReason:    SuspensionReasonRedCard,
+		},
+		{
+			name:          "red card after yellows keeps the yellow count",
+			competition:   standard,
+			played:        2,
+			bookings:      []bookingRow{yellow(1), yellow(2), card(2, models.EventRedCard)},
+			wantYellows:   2,
+			wantReds:      1,
+			wantRemaining: 1,
+			wantReason:    SuspensionReasonRedCard,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records := replayDiscipline(tt.competition, matches[:tt.played], tt.bookings)
+			rec, ok := records[player]
+			if !ok {
+				t.Fatal("no record for the booked player")
+			}
+			if rec.TeamID != 1 {
+				t.Errorf("got team %d, want 1", rec.TeamID)
+			}
+			if rec.YellowCards != tt.wantYellows || rec.RedCards != tt.wantReds {
+				t.Errorf("got %d yellow and %d red cards, want %d and %d", rec.YellowCards, rec.RedCards, tt.wantYellows, tt.wantReds)
+			}
+			if rec.MatchesRemaining != tt.wantRemaining {
+				t.Errorf("got %d matches remaining, want %d", rec.MatchesRemaining, tt.wantRemaining)
+			}
+			if rec.Reason != tt.wantReason {
+				t.Errorf("got reason %q, want %q", rec.Reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestReplayDisciplineWithoutBookings(t *testing.T) {
+	matches := []models.Match{{ID: 1, HomeTeamID: 1, AwayTeamID: 2}}
+	if records := replayDiscipline(models.Competition{YellowCardThreshold: 3}, matches, nil); len(records) != 0 {
+		t.Errorf("got %d records, want none", len(records))
+	}
+}
@@ -97,6 +97,8 @@ func UpdateTeam(c *gin.Context) {
 		return
 	}
 
+	renamed := team.Name != input.Name
+
 	team.Name = input.Name
 	team.Logo = input.Logo
 	team.FoundedYear = input.FoundedYear
@@ -108,6 +110,11 @@ func UpdateTeam(c *gin.Context) {
 		return
 	}
 
+	// Calendar events show the team's name
+	if renamed {
+		bumpCalendarSequence(config.DB, "home_team_id = ? OR away_team_id = ?", team.ID, team.ID)
+	}
+
 	utils.SuccessResponse(c, http.StatusOK, "Team updated successfully", team)
 }
 
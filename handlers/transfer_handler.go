@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"ayoindo/config"
+	"ayoindo/models"
+	"ayoindo/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type TransferInput struct {
+	TeamID       uint   `json:"team_id" binding:"required"`
+	Date         string `json:"date" binding:"omitempty,datetime=2006-01-02"`   // YYYY-MM-DD, defaults to today
+	JerseyNumber int    `json:"jersey_number" binding:"omitempty,min=1,max=99"` // defaults to the current number
+
+	// Admins only: allows the transfer outside a transfer window or during a roster freeze
+	OverrideReason string `json:"override_reason" binding:"omitempty,min=3,max=500"`
+}
+
+// today is the current date in the league's time zone
+func today() string {
+	return time.Now().In(config.Location()).Format("2006-01-02")
+}
+
+// playerTeamAt returns the team a player was registered to on the given date (YYYY-MM-DD)
+func playerTeamAt(db *gorm.DB, player models.Player, date string) uint {
+	var memberships []models.TeamMembership
+	db.Where("player_id = ?", player.ID).Order("from_date ASC, id ASC").Find(&memberships)
+	return teamOnDate(memberships, date, player.TeamID)
+}
+
+// teamOnDate picks the team of the membership that covers the date, the latest one if several do.
+// Before their first membership a player counts for that first team, and a player without any
+// membership for their current team.
+func teamOnDate(memberships []models.TeamMembership, date string, current uint) uint {
+	var team uint
+	var from string
+	for _, m := range memberships {
+		if m.FromDate <= date && (m.ToDate == nil || *m.ToDate > date) && m.FromDate >= from {
+			team, from = m.TeamID, m.FromDate
+		}
+	}
+	if team != 0 {
+		return team
+	}
+
+	var first *models.TeamMembership
+	for i := range memberships {
+		if first == nil || memberships[i].FromDate < first.FromDate {
+			first = &memberships[i]
+		}
+	}
+	if first != nil && date < first.FromDate {
+		return first.TeamID
+	}
+	return current
+}
+
+// loadMatchPlayer loads a player with TeamID set to their team on the date of the match,
+// so results and sheets of past matches are checked against the squad of the time
+func loadMatchPlayer(db *gorm.DB, id uint, match models.Match) (models.Player, error) {
+	var player models.Player
+	if err := db.First(&player, id).Error; err != nil {
+		return player, err
+	}
+	player.TeamID = playerTeamAt(db, player, match.MatchDate)
+	return player, nil
+}
+
+// currentMembership returns the open membership of a player, if any
+func currentMembership(db *gorm.DB, playerID uint) (models.TeamMembership, bool) {
+	var membership models.TeamMembership
+	err := db.Where("player_id = ? AND to_date IS NULL", playerID).Order("from_date DESC").First(&membership).Error
+	return membership, err == nil
+}
+
+// GetPlayerMemberships godoc
+// GET /api/players/:id/memberships
+func GetPlayerMemberships(c *gin.Context) {
+	id := c.Param("id")
+	var player models.Player
+	if err := config.DB.First(&player, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Player not found")
+		return
+	}
+
+	var memberships []models.TeamMembership
+	config.DB.Preload("Team").Preload("OverriddenBy").Where("player_id = ?", player.ID).Order("from_date ASC, id ASC").Find(&memberships)
+
+	utils.SuccessResponse(c, http.StatusOK, "Team memberships retrieved successfully", memberships)
+}
+
+// TransferPlayer godoc
+// POST /api/players/:id/transfer — ends the current membership and starts one at the new team
+func TransferPlayer(c *gin.Context) {
+	id := c.Param("id")
+	var player models.Player
+	if err := config.DB.First(&player, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Player not found")
+		return
+	}
+
+	var input TransferInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	var team models.Team
+	if err := config.DB.First(&team, input.TeamID).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Team not found")
+		return
+	}
+	if team.ID == player.TeamID {
+		utils.ValidationErrorResponse(c, "Player is already registered to this team")
+		return
+	}
+
+	date := input.Date
+	if date == "" {
+		date = today()
+	}
+	if date > today() {
+		utils.ValidationErrorResponse(c, "A transfer cannot be dated in the future")
+		return
+	}
+
+	current, hasCurrent := currentMembership(config.DB, player.ID)
+	if hasCurrent && date <= current.FromDate {
+		utils.ValidationErrorResponse(c, "A transfer must be dated after the player joined their current team on "+current.FromDate)
+		return
+	}
+
+	// Both squads must be open to changes on the transfer date
+	var oldTeam models.Team
+	teams := []models.Team{team}
+	if err := config.DB.First(&oldTeam, player.TeamID).Error; err == nil {
+		teams = []models.Team{oldTeam, team}
+	}
+	if !checkRosterChange(c, config.DB, teams, date, input.OverrideReason) {
+		return
+	}
+
+	// The player cannot leave before matches they were already named in for the old team
+	var named models.Match
+	if err := config.DB.
+		Joins("JOIN match_lineups ON match_lineups.match_id = matches.id AND match_lineups.deleted_at IS NULL").
+		Joins("JOIN lineup_players ON lineup_players.lineup_id = match_lineups.id AND lineup_players.deleted_at IS NULL").
+		Where("lineup_players.player_id = ? AND match_lineups.team_id = ? AND matches.match_date >= ? AND matches.status <> ?",
+			player.ID, player.TeamID, date, models.MatchStatusCancelled).
+		Order("matches.kickoff_at ASC").
+		First(&named).Error; err == nil {
+		utils.ErrorResponse(c, http.StatusConflict,
+			fmt.Sprintf("Player is in the lineup of their current team for match %d on %s", named.ID, named.MatchDate))
+		return
+	}
+
+	jersey := input.JerseyNumber
+	if jersey == 0 {
+		jersey = player.JerseyNumber
+	}
+	var taken models.Player
+	if err := config.DB.Where("team_id = ? AND jersey_number = ?", team.ID, jersey).First(&taken).Error; err == nil {
+		utils.ErrorResponse(c, http.StatusConflict, "Jersey number already taken in this team")
+		return
+	}
+
+	tx := config.DB.Begin()
+
+	if hasCurrent {
+		current.ToDate = &date
+		if err := tx.Save(&current).Error; err != nil {
+			tx.Rollback()
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to end current membership")
+			return
+		}
+	}
+
+	membership := models.TeamMembership{
+		PlayerID:     player.ID,
+		TeamID:       team.ID,
+		FromDate:     date,
+		JerseyNumber: jersey,
+	}
+	recordOverride(c, &membership, input.OverrideReason)
+	if err := tx.Create(&membership).Error; err != nil {
+		tx.Rollback()
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create membership")
+		return
+	}
+
+	player.TeamID = team.ID
+	player.JerseyNumber = jersey
+	if err := tx.Save(&player).Error; err != nil {
+		tx.Rollback()
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to transfer player")
+		return
+	}
+
+	tx.Commit()
+
+	config.DB.Preload("Team").First(&player, player.ID)
+	utils.SuccessResponse(c, http.StatusOK, "Player transferred successfully", player)
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"testing"
+
+	"ayoindo/models"
+)
+
+func TestTeamOnDate(t *testing.T) {
+	date := func(s string) *string { return &s }
+	const current = 9
+
+	// Team 5 until the transfer to team 6 on 2024-07-01, a loan to team 8 in September,
+	// then a gap until joining team 7
+	memberships := []models.TeamMembership{
+		{TeamID: 6, FromDate: "2024-07-01", ToDate: date("2024-12-31")},
+		{TeamID: 5, FromDate: "2024-01-01", ToDate: date("2024-07-01")},
+		{TeamID: 8, FromDate: "2024-09-01", ToDate: date("2024-10-01")},
+		{TeamID: 7, FromDate: "2025-02-01"},
+	}
+
+	tests := []struct {
+		name        string
+		memberships []models.TeamMembership
+		date        string
+		want        uint
+	}{
+		{name: "before the first membership", memberships: memberships, date: "2023-12-31", want: 5},
+		{name: "first day of the first membership", memberships: memberships, date: "2024-01-01", want: 5},
+		{name: "day before the transfer", memberships: memberships, date: "2024-06-30", want: 5},
+		{name: "transfer day belongs to the new team", memberships: memberships, date: "2024-07-01", want: 6},
+		{name: "loan overlapping a membership", memberships: memberships, date: "2024-09-15", want: 8},
+		{name: "back from loan", memberships: memberships, date: "2024-10-01", want: 6},
+		{name: "between memberships", memberships: memberships, date: "2025-01-15", want: current},
+		{name: "open membership", memberships: memberships, date: "2026-05-01", want: 7},
+		{name: "no memberships", date: "2024-03-01", want: current},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := teamOnDate(tt.memberships, tt.date, current); got != tt.want {
+				t.Errorf("teamOnDate on %s = %d, want %d", tt.date, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,284 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"ayoindo/config"
+	"ayoindo/models"
+	"ayoindo/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type TransferWindowInput struct {
+	Name      string `json:"name" binding:"required,min=2,max=100"`
+	StartDate string `json:"start_date" binding:"required,datetime=2006-01-02"`
+	EndDate   string `json:"end_date" binding:"required,datetime=2006-01-02"`
+}
+
+type RosterFreezeInput struct {
+	FromDate string  `json:"from_date" binding:"omitempty,datetime=2006-01-02"` // defaults to today
+	ToDate   *string `json:"to_date" binding:"omitempty,datetime=2006-01-02"`   // last frozen day, omit to freeze until lifted
+	Reason   string  `json:"reason" binding:"required,min=3,max=500"`
+}
+
+// RosterViolation is a rule that keeps a player from joining or leaving a team on a date
+type RosterViolation struct {
+	TeamID        uint   `json:"team_id"`
+	Rule          string `json:"rule"` // roster_freeze or transfer_window
+	CompetitionID uint   `json:"competition_id,omitempty"`
+	SeasonID      uint   `json:"season_id,omitempty"`
+	FreezeID      uint   `json:"freeze_id,omitempty"`
+	Message       string `json:"message"`
+}
+
+type RosterErrorData struct {
+	Date       string            `json:"date"`
+	Violations []RosterViolation `json:"violations"`
+}
+
+// rosterViolations lists why the squad of a team cannot change on the date (YYYY-MM-DD):
+// a roster freeze of the team, or a season in progress of a competition that has transfer
+// windows, none of them open on the date
+func rosterViolations(db *gorm.DB, team models.Team, date string) []RosterViolation {
+	violations := []RosterViolation{}
+
+	var freezes []models.RosterFreeze
+	db.Where("team_id = ? AND from_date <= ? AND (to_date IS NULL OR to_date >= ?)", team.ID, date, date).
+		Order("from_date ASC").Find(&freezes)
+	for _, freeze := range freezes {
+		until := "until lifted"
+		if freeze.ToDate != nil {
+			until = "until " + *freeze.ToDate
+		}
+		violations = append(violations, RosterViolation{
+			TeamID:   team.ID,
+			Rule:     "roster_freeze",
+			FreezeID: freeze.ID,
+			Message:  fmt.Sprintf("The roster of %s is frozen from %s %s: %s", team.Name, freeze.FromDate, until, freeze.Reason),
+		})
+	}
+
+	var seasons []models.Season
+	db.Preload("Competition").
+		Joins("JOIN season_teams ON season_teams.season_id = seasons.id").
+		Where("season_teams.team_id = ? AND seasons.start_date <= ? AND seasons.end_date >= ?", team.ID, date, date).
+		Order("seasons.id ASC").Find(&seasons)
+	for _, season := range seasons {
+		var windows, open int64
+		db.Model(&models.TransferWindow{}).Where("competition_id = ?", season.CompetitionID).Count(&windows)
+		if windows == 0 {
+			continue
+		}
+		db.Model(&models.TransferWindow{}).
+			Where("competition_id = ? AND start_date <= ? AND end_date >= ?", season.CompetitionID, date, date).
+			Count(&open)
+		if open > 0 {
+			continue
+		}
+		competition := season.Name
+		if season.Competition != nil {
+			competition = season.Competition.Name + " " + season.Name
+		}
+		violations = append(violations, RosterViolation{
+			TeamID:        team.ID,
+			Rule:          "transfer_window",
+			CompetitionID: season.CompetitionID,
+			SeasonID:      season.ID,
+			Message:       fmt.Sprintf("%s plays in %s and no transfer window is open on %s", team.Name, competition, date),
+		})
+	}
+	return violations
+}
+
+// checkRosterChange writes an error and returns false when the squads of the teams cannot
+// change on the date. An admin may go ahead anyway by giving an override reason, which the
+// caller records on the new membership.
+func checkRosterChange(c *gin.Context, db *gorm.DB, teams []models.Team, date, overrideReason string) bool {
+	if overrideReason != "" && c.GetString("role") != models.RoleAdmin {
+		utils.ErrorResponse(c, http.StatusForbidden, "Only admins can override transfer windows and roster freezes")
+		return false
+	}
+
+	violations := []RosterViolation{}
+	for _, team := range teams {
+		violations = append(violations, rosterViolations(db, team, date)...)
+	}
+	if len(violations) == 0 || overrideReason != "" {
+		return true
+	}
+	utils.ErrorResponseWithData(c, http.StatusConflict,
+		fmt.Sprintf("Roster changes are closed on %s; an admin can override with override_reason", date),
+		RosterErrorData{Date: date, Violations: violations})
+	return false
+}
+
+// recordOverride marks a membership as allowed by the current admin despite the roster rules
+func recordOverride(c *gin.Context, membership *models.TeamMembership, reason string) {
+	if reason == "" {
+		return
+	}
+	userID := currentUserID(c)
+	membership.OverrideReason = reason
+	membership.OverriddenByID = &userID
+}
+
+// GetTransferWindows godoc
+// GET /api/competitions/:id/transfer-windows
+func GetTransferWindows(c *gin.Context) {
+	var competition models.Competition
+	if err := config.DB.First(&competition, c.Param("id")).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Competition not found")
+		return
+	}
+
+	var windows []models.TransferWindow
+	config.DB.Where("competition_id = ?", competition.ID).Order("start_date ASC, id ASC").Find(&windows)
+
+	utils.SuccessResponse(c, http.StatusOK, "Transfer windows retrieved successfully", windows)
+}
+
+// CreateTransferWindow godoc
+// POST /api/competitions/:id/transfer-windows
+func CreateTransferWindow(c *gin.Context) {
+	var competition models.Competition
+	if err := config.DB.First(&competition, c.Param("id")).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Competition not found")
+		return
+	}
+
+	var input TransferWindowInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+	if input.EndDate < input.StartDate {
+		utils.ValidationErrorResponse(c, "end_date must not be before start_date")
+		return
+	}
+
+	var overlapping models.TransferWindow
+	if err := config.DB.Where("competition_id = ? AND start_date <= ? AND end_date >= ?", competition.ID, input.EndDate, input.StartDate).
+		First(&overlapping).Error; err == nil {
+		utils.ErrorResponse(c, http.StatusConflict, "Transfer window overlaps with "+overlapping.Name)
+		return
+	}
+
+	window := models.TransferWindow{
+		CompetitionID: competition.ID,
+		Name:          input.Name,
+		StartDate:     input.StartDate,
+		EndDate:       input.EndDate,
+	}
+	if err := config.DB.Create(&window).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create transfer window")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Transfer window created successfully", window)
+}
+
+// DeleteTransferWindow godoc
+// DELETE /api/competitions/:id/transfer-windows/:window_id — soft delete
+func DeleteTransferWindow(c *gin.Context) {
+	var window models.TransferWindow
+	if err := config.DB.Where("competition_id = ?", c.Param("id")).First(&window, c.Param("window_id")).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Transfer window not found")
+		return
+	}
+
+	if err := config.DB.Delete(&window).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete transfer window")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Transfer window deleted successfully", nil)
+}
+
+// GetRosterFreezes godoc
+// GET /api/teams/:id/roster-freezes
+func GetRosterFreezes(c *gin.Context) {
+	var team models.Team
+	if err := config.DB.First(&team, c.Param("id")).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Team not found")
+		return
+	}
+
+	var freezes []models.RosterFreeze
+	config.DB.Preload("CreatedBy").Where("team_id = ?", team.ID).Order("from_date ASC, id ASC").Find(&freezes)
+
+	utils.SuccessResponse(c, http.StatusOK, "Roster freezes retrieved successfully", freezes)
+}
+
+// CreateRosterFreeze godoc
+// POST /api/teams/:id/roster-freezes
+func CreateRosterFreeze(c *gin.Context) {
+	var team models.Team
+	if err := config.DB.First(&team, c.Param("id")).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Team not found")
+		return
+	}
+
+	var input RosterFreezeInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+	if input.FromDate == "" {
+		input.FromDate = today()
+	}
+	if input.ToDate != nil && *input.ToDate < input.FromDate {
+		utils.ValidationErrorResponse(c, "to_date must not be before from_date")
+		return
+	}
+
+	freeze := models.RosterFreeze{
+		TeamID:      team.ID,
+		FromDate:    input.FromDate,
+		ToDate:      input.ToDate,
+		Reason:      input.Reason,
+		CreatedByID: currentUserID(c),
+	}
+	if err := config.DB.Create(&freeze).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create roster freeze")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Roster freeze created successfully", freeze)
+}
+
+// LiftRosterFreeze godoc
+// DELETE /api/teams/:id/roster-freezes/:freeze_id — ends the freeze yesterday, or removes it if it has not started
+func LiftRosterFreeze(c *gin.Context) {
+	var freeze models.RosterFreeze
+	if err := config.DB.Where("team_id = ?", c.Param("id")).First(&freeze, c.Param("freeze_id")).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Roster freeze not found")
+		return
+	}
+
+	date := today()
+	if freeze.FromDate >= date {
+		if err := config.DB.Delete(&freeze).Error; err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to lift roster freeze")
+			return
+		}
+		utils.SuccessResponse(c, http.StatusOK, "Roster freeze lifted successfully", nil)
+		return
+	}
+
+	// Keep the days already frozen so past transfers can still be explained
+	now, _ := time.Parse("2006-01-02", date)
+	lastDay := now.AddDate(0, 0, -1).Format("2006-01-02")
+	if freeze.ToDate == nil || *freeze.ToDate > lastDay {
+		freeze.ToDate = &lastDay
+		if err := config.DB.Save(&freeze).Error; err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to lift roster freeze")
+			return
+		}
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Roster freeze lifted successfully", freeze)
+}
@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ayoindo/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCheckRosterChangeOverrideNeedsAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, role := range []string{models.RoleUser, ""} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		if role != "" {
+			c.Set("role", role)
+		}
+
+		if checkRosterChange(c, nil, nil, "2025-10-01", "Emergency goalkeeper signing") {
+			t.Errorf("role %q: override accepted, want it rejected", role)
+		}
+		if w.Code != http.StatusForbidden {
+			t.Errorf("role %q: got status %d, want %d", role, w.Code, http.StatusForbidden)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"ayoindo/config"
+	"ayoindo/models"
+	"ayoindo/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type VenueInput struct {
+	Name      string              `json:"name" binding:"required,min=2,max=100"`
+	Address   string              `json:"address" binding:"required"`
+	City      string              `json:"city" binding:"required"`
+	Capacity  int                 `json:"capacity" binding:"min=0"`
+	Latitude  *float64            `json:"latitude" binding:"omitempty,min=-90,max=90"`
+	Longitude *float64            `json:"longitude" binding:"omitempty,min=-180,max=180"`
+	Surface   models.VenueSurface `json:"surface"`                               // defaults to grass
+	Timezone  string              `json:"timezone" binding:"omitempty,timezone"` // IANA name, empty to use the competition's
+}
+
+func isValidVenueSurface(surface models.VenueSurface) bool {
+	switch surface {
+	case models.SurfaceGrass, models.SurfaceArtificial, models.SurfaceHybrid:
+		return true
+	}
+	return false
+}
+
+// validateVenueInput fills in the default surface and checks the coordinates come as a pair
+func validateVenueInput(c *gin.Context, input *VenueInput) bool {
+	if input.Surface == "" {
+		input.Surface = models.SurfaceGrass
+	}
+	if !isValidVenueSurface(input.Surface) {
+		utils.ValidationErrorResponse(c, "Invalid surface. Must be one of: grass, artificial, hybrid")
+		return false
+	}
+	if (input.Latitude == nil) != (input.Longitude == nil) {
+		utils.ValidationErrorResponse(c, "Latitude and longitude must be given together")
+		return false
+	}
+	return true
+}
+
+// sameCoordinate reports whether two optional coordinates are equal
+func sameCoordinate(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// validateMatchVenue checks that the venue of a match exists and is not booked for another
+// match that overlaps it. Cancelled matches free their slot, and so do postponed ones: their
+// old kickoff no longer holds, and the venue is checked again when they are rescheduled.
+func validateMatchVenue(c *gin.Context, match models.Match) bool {
+	if match.VenueID == nil {
+		return true
+	}
+
+	var venue models.Venue
+	if err := config.DB.First(&venue, *match.VenueID).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Venue not found")
+		return false
+	}
+
+	var others []models.Match
+	config.DB.
+		Where("venue_id = ? AND id <> ? AND status NOT IN ?", venue.ID, match.ID,
+			[]models.MatchStatus{models.MatchStatusCancelled, models.MatchStatusPostponed}).
+		Find(&others)
+	for _, other := range others {
+		if matchesOverlap(match, other) {
+			utils.ErrorResponse(c, http.StatusConflict,
+				fmt.Sprintf("Venue is already booked for match %d on %s %s", other.ID, other.MatchDate, other.MatchTime))
+			return false
+		}
+	}
+	return true
+}
+
+// GetAllVenues godoc
+// GET /api/venues
+func GetAllVenues(c *gin.Context) {
+	var venues []models.Venue
+	query := config.DB.Model(&models.Venue{})
+
+	if city := c.Query("city"); city != "" {
+		query = query.Where("city = ?", city)
+	}
+	if surface := c.Query("surface"); surface != "" {
+		query = query.Where("surface = ?", surface)
+	}
+
+	var total int64
+	query.Count(&total)
+	query.Order("name ASC").Find(&venues)
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Venues retrieved successfully",
+		"data":    venues,
+		"total":   total,
+	})
+}
+
+// GetVenueByID godoc
+// GET /api/venues/:id
+func GetVenueByID(c *gin.Context) {
+	id := c.Param("id")
+	var venue models.Venue
+
+	if err := config.DB.First(&venue, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Venue not found")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Venue retrieved successfully", venue)
+}
+
+// CreateVenue godoc
+// POST /api/venues
+func CreateVenue(c *gin.Context) {
+	var input VenueInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	if !validateVenueInput(c, &input) {
+		return
+	}
+
+	venue := models.Venue{
+		Name:      input.Name,
+		Address:   input.Address,
+		City:      input.City,
+		Capacity:  input.Capacity,
+		Latitude:  input.Latitude,
+		Longitude: input.Longitude,
+		Surface:   input.Surface,
+		Timezone:  input.Timezone,
+	}
+
+	if err := config.DB.Create(&venue).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create venue")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Venue created successfully", venue)
+}
+
+// UpdateVenue godoc
+// PUT /api/venues/:id
+func UpdateVenue(c *gin.Context) {
+	id := c.Param("id")
+	var venue models.Venue
+
+	if err := config.DB.First(&venue, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Venue not found")
+		return
+	}
+
+	var input VenueInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.ValidationErrorResponse(c, err.Error())
+		return
+	}
+
+	if !validateVenueInput(c, &input) {
+		return
+	}
+
+	// Calendar events show the venue's name, address and coordinates
+	located := venue.Name != input.Name || venue.Address != input.Address || venue.City != input.City ||
+		!sameCoordinate(venue.Latitude, input.Latitude) || !sameCoordinate(venue.Longitude, input.Longitude)
+
+	venue.Name = input.Name
+	venue.Address = input.Address
+	venue.City = input.City
+	venue.Capacity = input.Capacity
+	venue.Latitude = input.Latitude
+	venue.Longitude = input.Longitude
+	venue.Surface = input.Surface
+	venue.Timezone = input.Timezone
+
+	if err := config.DB.Save(&venue).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update venue")
+		return
+	}
+
+	if located {
+		bumpCalendarSequence(config.DB, "venue_id = ?", venue.ID)
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Venue updated successfully", venue)
+}
+
+// DeleteVenue godoc
+// DELETE /api/venues/:id — soft delete, only without upcoming matches
+func DeleteVenue(c *gin.Context) {
+	id := c.Param("id")
+	var venue models.Venue
+
+	if err := config.DB.First(&venue, id).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, "Venue not found")
+		return
+	}
+
+	var upcoming int64
+	config.DB.Model(&models.Match{}).
+		Where("venue_id = ? AND status IN ?", venue.ID, models.OpenMatchStatuses).
+		Count(&upcoming)
+	if upcoming > 0 {
+		utils.ErrorResponse(c, http.StatusConflict, "Cannot delete a venue that still has matches to be played")
+		return
+	}
+
+	if err := config.DB.Delete(&venue).Error; err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete venue")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Venue deleted successfully", nil)
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"strings"
 
+	"ayoindo/models"
 	"ayoindo/utils"
 
 	"github.com/gin-gonic/gin"
@@ -63,3 +64,15 @@ func AuthMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// AdminOnly rejects users without the admin role; it runs after AuthMiddleware
+func AdminOnly() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString("role") != models.RoleAdmin {
+			utils.ErrorResponse(c, http.StatusForbidden, "Admin access required")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
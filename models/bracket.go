@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// KnockoutStageBracketName is the name of the bracket seeded from a group stage
+const KnockoutStageBracketName = "Knockout Stage"
+
+// Bracket is a single-elimination tree. Round 1 holds Size/2 slots and the final is round Rounds.
+type Bracket struct {
+	ID           uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	SeasonID     uint           `json:"season_id" gorm:"not null;index"`
+	Season       *Season        `json:"season,omitempty" gorm:"foreignKey:SeasonID"`
+	Name         string         `json:"name" gorm:"not null"`
+	Size         int            `json:"size" gorm:"not null"`       // number of round 1 positions, a power of two
+	Rounds       int            `json:"rounds" gorm:"not null"`     // log2(Size)
+	StartDate    string         `json:"start_date" gorm:"not null"` // YYYY-MM-DD, date of round 1
+	IntervalDays int            `json:"interval_days" gorm:"not null"`
+	MatchTime    string         `json:"match_time" gorm:"not null"`                // HH:MM
+	GroupStage   bool           `json:"group_stage" gorm:"not null;default:false"` // seeded from the season's group stage
+	Slots        []BracketSlot  `json:"slots,omitempty" gorm:"foreignKey:BracketID"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// BracketSlot is one tie in a bracket. The winner of slot (round, position) moves on to
+// slot (round+1, position/2), on the home side for even positions and the away side for odd ones.
+type BracketSlot struct {
+	ID           uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	BracketID    uint           `json:"bracket_id" gorm:"not null;index"`
+	Round        int            `json:"round" gorm:"not null"`
+	Position     int            `json:"position" gorm:"not null"` // 0-based within the round
+	HomeSeed     int            `json:"home_seed,omitempty"`      // round 1 only
+	AwaySeed     int            `json:"away_seed,omitempty"`      // round 1 only
+	HomeTeamID   *uint          `json:"home_team_id"`
+	AwayTeamID   *uint          `json:"away_team_id"`
+	HomeTeam     *Team          `json:"home_team,omitempty" gorm:"foreignKey:HomeTeamID"`
+	AwayTeam     *Team          `json:"away_team,omitempty" gorm:"foreignKey:AwayTeamID"`
+	IsBye        bool           `json:"is_bye" gorm:"default:false"`
+	MatchID      *uint          `json:"match_id" gorm:"index"`
+	Match        *Match         `json:"match,omitempty" gorm:"foreignKey:MatchID"`
+	WinnerTeamID *uint          `json:"winner_team_id"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CompetitionFormat defines how a competition is played
+type CompetitionFormat string
+
+const (
+	CompetitionFormatLeague CompetitionFormat = "league"
+	CompetitionFormatCup    CompetitionFormat = "cup"
+
+	// Groups play a round-robin first, then the qualifiers meet in a knockout bracket
+	CompetitionFormatGroupKnockout CompetitionFormat = "group_knockout"
+)
+
+// Default disciplinary rules: three yellow cards or one red card earn a one-match ban.
+// Cards are counted across all seasons of a competition.
+const (
+	DefaultYellowCardThreshold = 3
+	DefaultYellowCardBan       = 1
+	DefaultRedCardBan          = 1
+)
+
+// DefaultTimezone is the time zone of kickoffs when neither the venue nor the competition sets one
+const DefaultTimezone = "Asia/Jakarta"
+
+// DefaultMatchLength is the regulation playing time in minutes; extra time adds ExtraTimeLength
+const (
+	DefaultMatchLength = 90
+	ExtraTimeLength    = 30
+)
+
+type Competition struct {
+	ID                  uint              `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name                string            `json:"name" gorm:"not null"`
+	Format              CompetitionFormat `json:"format" gorm:"not null;default:'league'"`
+	Description         string            `json:"description"`
+	Tier                int               `json:"tier" gorm:"not null;default:0"`                  // division level, 1 = top flight, 0 = not part of a pyramid
+	YellowCardThreshold int               `json:"yellow_card_threshold" gorm:"not null;default:3"` // yellow cards per ban, 0 = no accumulation bans
+	YellowCardBan       int               `json:"yellow_card_ban" gorm:"not null;default:1"`       // matches banned on reaching the threshold
+	RedCardBan          int               `json:"red_card_ban" gorm:"not null;default:1"`          // matches banned after a red card or second yellow
+	MatchLength         int               `json:"match_length" gorm:"not null;default:90"`         // regulation minutes, used for minutes played
+	Timezone            string            `json:"timezone" gorm:"not null;default:'Asia/Jakarta'"` // IANA time zone of kickoffs, unless the venue has its own
+	Seasons             []Season          `json:"seasons,omitempty" gorm:"foreignKey:CompetitionID"`
+	CreatedAt           time.Time         `json:"created_at"`
+	UpdatedAt           time.Time         `json:"updated_at"`
+	DeletedAt           gorm.DeletedAt    `json:"-" gorm:"index"`
+}
@@ -6,13 +6,42 @@ import (
 	"gorm.io/gorm"
 )
 
+// GoalKind defines how a goal was scored
+type GoalKind string
+
+const (
+	GoalKindNormal  GoalKind = "normal"
+	GoalKindPenalty GoalKind = "penalty"
+	GoalKindOwnGoal GoalKind = "own_goal" // counts for the opponent of the player's team
+)
+
+// IsValid reports whether the kind is a known goal kind
+func (k GoalKind) IsValid() bool {
+	return k == GoalKindNormal || k == GoalKindPenalty || k == GoalKindOwnGoal
+}
+
+// EventType returns the timeline event type of a goal of this kind
+func (k GoalKind) EventType() MatchEventType {
+	switch k {
+	case GoalKindPenalty:
+		return EventPenaltyGoal
+	case GoalKindOwnGoal:
+		return EventOwnGoal
+	}
+	return EventGoal
+}
+
 type Goal struct {
-	ID            uint           `json:"id" gorm:"primaryKey;autoIncrement"`
-	MatchResultID uint           `json:"match_result_id" gorm:"not null"`
-	PlayerID      uint           `json:"player_id" gorm:"not null"`
-	Player        *Player        `json:"player,omitempty" gorm:"foreignKey:PlayerID"`
-	Minute        int            `json:"minute" gorm:"not null"` // minute when goal occurred
-	CreatedAt     time.Time      `json:"created_at"`
-	UpdatedAt     time.Time      `json:"updated_at"`
-	DeletedAt     gorm.DeletedAt `json:"-" gorm:"index"`
+	ID             uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	MatchResultID  uint           `json:"match_result_id" gorm:"not null"`
+	PlayerID       uint           `json:"player_id" gorm:"not null"`
+	Player         *Player        `json:"player,omitempty" gorm:"foreignKey:PlayerID"`
+	TeamID         uint           `json:"team_id" gorm:"not null;default:0"` // team of the scorer on the match date, not the team they score for with an own goal
+	Minute         int            `json:"minute" gorm:"not null"`            // minute when goal occurred
+	Kind           GoalKind       `json:"kind" gorm:"default:'normal'"`
+	AssistPlayerID *uint          `json:"assist_player_id"`
+	AssistPlayer   *Player        `json:"assist_player,omitempty" gorm:"foreignKey:AssistPlayerID"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `json:"-" gorm:"index"`
 }
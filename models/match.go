@@ -10,18 +10,74 @@ import (
 type MatchStatus string
 
 const (
-	MatchStatusScheduled MatchStatus = "scheduled"
-	MatchStatusCompleted MatchStatus = "completed"
+	MatchStatusScheduled  MatchStatus = "scheduled"
+	MatchStatusInProgress MatchStatus = "in_progress"
+	MatchStatusCompleted  MatchStatus = "completed"
+	MatchStatusForfeited  MatchStatus = "forfeited" // decided by a walkover, see ResultTypeForfeit
+	MatchStatusPostponed  MatchStatus = "postponed"
+	MatchStatusCancelled  MatchStatus = "cancelled"
+	MatchStatusAbandoned  MatchStatus = "abandoned"
 )
 
+// matchStatusTransitions lists the statuses a match may move to from each status.
+// Completed, forfeited and cancelled are final.
+var matchStatusTransitions = map[MatchStatus][]MatchStatus{
+	MatchStatusScheduled:  {MatchStatusInProgress, MatchStatusCompleted, MatchStatusForfeited, MatchStatusPostponed, MatchStatusCancelled},
+	MatchStatusInProgress: {MatchStatusCompleted, MatchStatusForfeited, MatchStatusAbandoned},
+	MatchStatusPostponed:  {MatchStatusScheduled, MatchStatusForfeited, MatchStatusCancelled},
+	MatchStatusAbandoned:  {MatchStatusScheduled, MatchStatusCompleted, MatchStatusForfeited, MatchStatusCancelled},
+}
+
+// OpenMatchStatuses are the statuses of matches that still have to be played or decided
+var OpenMatchStatuses = []MatchStatus{
+	MatchStatusScheduled, MatchStatusInProgress, MatchStatusPostponed, MatchStatusAbandoned,
+}
+
+// DecidedMatchStatuses are the statuses of matches that have a result
+var DecidedMatchStatuses = []MatchStatus{MatchStatusCompleted, MatchStatusForfeited}
+
+// IsDecided reports whether a match in status s has a result
+func (s MatchStatus) IsDecided() bool {
+	return s == MatchStatusCompleted || s == MatchStatusForfeited
+}
+
+// IsValid reports whether the status is a known match status
+func (s MatchStatus) IsValid() bool {
+	if s.IsDecided() || s == MatchStatusCancelled {
+		return true
+	}
+	_, ok := matchStatusTransitions[s]
+	return ok
+}
+
+// CanTransitionTo reports whether a match in status s may move to next
+func (s MatchStatus) CanTransitionTo(next MatchStatus) bool {
+	for _, allowed := range matchStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
 type Match struct {
 	ID          uint           `json:"id" gorm:"primaryKey;autoIncrement"`
 	HomeTeamID  uint           `json:"home_team_id" gorm:"not null"`
 	AwayTeamID  uint           `json:"away_team_id" gorm:"not null"`
+	SeasonID    *uint          `json:"season_id" gorm:"index"`
+	Season      *Season        `json:"season,omitempty" gorm:"foreignKey:SeasonID"`
+	GroupID     *uint          `json:"group_id" gorm:"index"`
+	Group       *SeasonGroup   `json:"group,omitempty" gorm:"foreignKey:GroupID"`
+	Matchday    int            `json:"matchday,omitempty"` // round number within the season, 0 when not part of a schedule
+	VenueID     *uint          `json:"venue_id" gorm:"index"`
+	Venue       *Venue         `json:"venue,omitempty" gorm:"foreignKey:VenueID"`
 	HomeTeam    *Team          `json:"home_team,omitempty" gorm:"foreignKey:HomeTeamID"`
 	AwayTeam    *Team          `json:"away_team,omitempty" gorm:"foreignKey:AwayTeamID"`
-	MatchDate   string         `json:"match_date" gorm:"not null"` // YYYY-MM-DD
-	MatchTime   string         `json:"match_time" gorm:"not null"` // HH:MM
+	MatchDate   string         `json:"match_date" gorm:"not null"` // YYYY-MM-DD, local to Timezone
+	MatchTime   string         `json:"match_time" gorm:"not null"` // HH:MM, local to Timezone
+	KickoffAt   *time.Time     `json:"kickoff_at" gorm:"index"`
+	Timezone    string         `json:"timezone" gorm:"not null;default:'Asia/Jakarta'"` // IANA time zone of the venue or competition
+	Sequence    int            `json:"sequence" gorm:"not null;default:0"`              // revision of the schedule, bumped on every change for calendar feeds
 	Status      MatchStatus    `json:"status" gorm:"default:'scheduled'"`
 	MatchResult *MatchResult   `json:"match_result,omitempty" gorm:"foreignKey:MatchID"`
 	CreatedAt   time.Time      `json:"created_at"`
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MatchEventType defines what happened in a match event
+type MatchEventType string
+
+const (
+	EventGoal          MatchEventType = "goal"
+	EventPenaltyGoal   MatchEventType = "penalty_goal"
+	EventMissedPenalty MatchEventType = "missed_penalty"
+	EventOwnGoal       MatchEventType = "own_goal"
+	EventYellowCard    MatchEventType = "yellow_card"
+	EventSecondYellow  MatchEventType = "second_yellow"
+	EventRedCard       MatchEventType = "red_card"
+	EventSubstitution  MatchEventType = "substitution"
+	EventVAR           MatchEventType = "var"
+	EventOther         MatchEventType = "other"
+)
+
+// IsGoal reports whether the event is a goal; goal events are recorded from the result's goals
+func (t MatchEventType) IsGoal() bool {
+	return t == EventGoal || t == EventPenaltyGoal || t == EventOwnGoal
+}
+
+// MatchEvent is one entry of a match timeline. For a substitution PlayerID is the player
+// coming on and RelatedPlayerID the player going off; for a goal RelatedPlayerID is the
+// assisting player. VAR and other events may have no player.
+type MatchEvent struct {
+	ID              uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	MatchResultID   uint           `json:"match_result_id" gorm:"not null;index"`
+	Type            MatchEventType `json:"type" gorm:"not null"`
+	Minute          int            `json:"minute" gorm:"not null"`
+	TeamID          *uint          `json:"team_id"`
+	Team            *Team          `json:"team,omitempty" gorm:"foreignKey:TeamID"`
+	PlayerID        *uint          `json:"player_id"`
+	Player          *Player        `json:"player,omitempty" gorm:"foreignKey:PlayerID"`
+	RelatedPlayerID *uint          `json:"related_player_id"`
+	RelatedPlayer   *Player        `json:"related_player,omitempty" gorm:"foreignKey:RelatedPlayerID"`
+	Description     string         `json:"description,omitempty"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `json:"-" gorm:"index"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MatchLineup is the squad one team named for a match. A locked lineup can no longer be changed.
+type MatchLineup struct {
+	ID        uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	MatchID   uint           `json:"match_id" gorm:"not null;uniqueIndex:idx_match_lineup_team"`
+	TeamID    uint           `json:"team_id" gorm:"not null;uniqueIndex:idx_match_lineup_team"`
+	Team      *Team          `json:"team,omitempty" gorm:"foreignKey:TeamID"`
+	CaptainID uint           `json:"captain_id" gorm:"not null"`
+	Captain   *Player        `json:"captain,omitempty" gorm:"foreignKey:CaptainID"`
+	Locked    bool           `json:"locked" gorm:"default:false"`
+	LockedAt  *time.Time     `json:"locked_at"`
+	Players   []LineupPlayer `json:"players,omitempty" gorm:"foreignKey:LineupID"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// LineupPlayer is a starter or substitute named in a lineup
+type LineupPlayer struct {
+	ID           uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	LineupID     uint           `json:"lineup_id" gorm:"not null;index"`
+	PlayerID     uint           `json:"player_id" gorm:"not null"`
+	Player       *Player        `json:"player,omitempty" gorm:"foreignKey:PlayerID"`
+	JerseyNumber int            `json:"jersey_number" gorm:"not null"`
+	Position     PlayerPosition `json:"position" gorm:"not null"`
+	Starter      bool           `json:"starter" gorm:"not null"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+}
@@ -6,13 +6,35 @@ import (
 	"gorm.io/gorm"
 )
 
+// ResultType tells how a result came about
+type ResultType string
+
+const (
+	ResultTypeNormal  ResultType = "normal"
+	ResultTypeForfeit ResultType = "forfeit" // walkover: awarded score, no goals
+)
+
+// MatchResult scores include extra time. The extra time scores are the part of
+// HomeScore/AwayScore scored after 90 minutes; penalty scores are nil without a shootout.
+// A forfeit carries the awarded score and has no goals.
 type MatchResult struct {
-	ID        uint           `json:"id" gorm:"primaryKey;autoIncrement"`
-	MatchID   uint           `json:"match_id" gorm:"uniqueIndex;not null"`
-	HomeScore int            `json:"home_score" gorm:"default:0"`
-	AwayScore int            `json:"away_score" gorm:"default:0"`
-	Goals     []Goal         `json:"goals,omitempty" gorm:"foreignKey:MatchResultID"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+	ID                 uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	MatchID            uint           `json:"match_id" gorm:"uniqueIndex;not null"`
+	ResultType         ResultType     `json:"result_type" gorm:"default:'normal'"`
+	HomeScore          int            `json:"home_score" gorm:"default:0"`
+	AwayScore          int            `json:"away_score" gorm:"default:0"`
+	ForfeitingTeamID   *uint          `json:"forfeiting_team_id"` // forfeit only: the team that did not play
+	ForfeitingTeam     *Team          `json:"forfeiting_team,omitempty" gorm:"foreignKey:ForfeitingTeamID"`
+	ForfeitReason      string         `json:"forfeit_reason,omitempty"`
+	ExtraTime          bool           `json:"extra_time" gorm:"default:false"`
+	HomeExtraTimeScore int            `json:"home_extra_time_score" gorm:"default:0"`
+	AwayExtraTimeScore int            `json:"away_extra_time_score" gorm:"default:0"`
+	HomePenaltyScore   *int           `json:"home_penalty_score"`
+	AwayPenaltyScore   *int           `json:"away_penalty_score"`
+	Goals              []Goal         `json:"goals,omitempty" gorm:"foreignKey:MatchResultID"`
+	ShootoutKicks      []ShootoutKick `json:"shootout_kicks,omitempty" gorm:"foreignKey:MatchResultID"`
+	Events             []MatchEvent   `json:"events,omitempty" gorm:"foreignKey:MatchResultID"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+	DeletedAt          gorm.DeletedAt `json:"-" gorm:"index"`
 }
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+)
+
+// TeamMatchStats is the stat sheet of one team in a match
+type TeamMatchStats struct {
+	ID            uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	MatchID       uint      `json:"match_id" gorm:"not null;uniqueIndex:idx_team_match_stats"`
+	TeamID        uint      `json:"team_id" gorm:"not null;uniqueIndex:idx_team_match_stats"`
+	Team          *Team     `json:"team,omitempty" gorm:"foreignKey:TeamID"`
+	Shots         int       `json:"shots" gorm:"not null"`
+	ShotsOnTarget int       `json:"shots_on_target" gorm:"not null"`
+	Corners       int       `json:"corners" gorm:"not null"`
+	Fouls         int       `json:"fouls" gorm:"not null"`
+	Offsides      int       `json:"offsides" gorm:"not null"`
+	Possession    *int      `json:"possession"` // percentage, nil when not tracked
+	Saves         int       `json:"saves" gorm:"not null"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// PlayerMatchStats is the stat sheet of one player in a match
+type PlayerMatchStats struct {
+	ID            uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	MatchID       uint      `json:"match_id" gorm:"not null;uniqueIndex:idx_player_match_stats"`
+	PlayerID      uint      `json:"player_id" gorm:"not null;uniqueIndex:idx_player_match_stats"`
+	Player        *Player   `json:"player,omitempty" gorm:"foreignKey:PlayerID"`
+	TeamID        uint      `json:"team_id" gorm:"not null"`
+	Shots         int       `json:"shots" gorm:"not null"`
+	ShotsOnTarget int       `json:"shots_on_target" gorm:"not null"`
+	Passes        int       `json:"passes" gorm:"not null"`
+	Tackles       int       `json:"tackles" gorm:"not null"`
+	Fouls         int       `json:"fouls" gorm:"not null"`
+	Saves         int       `json:"saves" gorm:"not null"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
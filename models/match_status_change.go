@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+// MatchStatusChange is the audit trail of a match's lifecycle
+type MatchStatusChange struct {
+	ID          uint        `json:"id" gorm:"primaryKey;autoIncrement"`
+	MatchID     uint        `json:"match_id" gorm:"not null;index"`
+	FromStatus  MatchStatus `json:"from_status" gorm:"not null"`
+	ToStatus    MatchStatus `json:"to_status" gorm:"not null"`
+	Reason      string      `json:"reason" gorm:"not null"`
+	ChangedByID uint        `json:"changed_by_id" gorm:"not null"`
+	ChangedBy   *User       `json:"changed_by,omitempty" gorm:"foreignKey:ChangedByID"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
@@ -0,0 +1,103 @@
+package models
+
+import "testing"
+
+var allMatchStatuses = []MatchStatus{
+	MatchStatusScheduled, MatchStatusInProgress, MatchStatusCompleted, MatchStatusForfeited,
+	MatchStatusPostponed, MatchStatusCancelled, MatchStatusAbandoned,
+}
+
+func TestCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from    MatchStatus
+		allowed []MatchStatus // every other status must be rejected
+	}{
+		{from: MatchStatusScheduled, allowed: []MatchStatus{MatchStatusInProgress, MatchStatusCompleted, MatchStatusForfeited, MatchStatusPostponed, MatchStatusCancelled}},
+		{from: MatchStatusInProgress, allowed: []MatchStatus{MatchStatusCompleted, MatchStatusForfeited, MatchStatusAbandoned}},
+		{from: MatchStatusPostponed, allowed: []MatchStatus{MatchStatusScheduled, MatchStatusForfeited, MatchStatusCancelled}},
+		{from: MatchStatusAbandoned, allowed: []MatchStatus{MatchStatusScheduled, MatchStatusCompleted, MatchStatusForfeited, MatchStatusCancelled}},
+		{from: MatchStatusCompleted},
+		{from: MatchStatusForfeited},
+		{from: MatchStatusCancelled},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from), func(t *testing.T) {
+			allowed := make(map[MatchStatus]bool)
+			for _, s := range tt.allowed {
+				allowed[s] = true
+			}
+			for _, next := range allMatchStatuses {
+				if got := tt.from.CanTransitionTo(next); got != allowed[next] {
+					t.Errorf("%s -> %s: got %v, want %v", tt.from, next, got, allowed[next])
+				}
+			}
+			if tt.from.CanTransitionTo(MatchStatus("unknown")) {
+				t.Errorf("%s -> unknown status allowed", tt.from)
+			}
+		})
+	}
+}
+
+func TestPostponedMatchMustBeRescheduledBeforeItIsPlayed(t *testing.T) {
+	if MatchStatusPostponed.CanTransitionTo(MatchStatusCompleted) || MatchStatusPostponed.CanTransitionTo(MatchStatusInProgress) {
+		t.Fatal("a postponed match can be played without being rescheduled")
+	}
+	if !MatchStatusPostponed.CanTransitionTo(MatchStatusScheduled) || !MatchStatusScheduled.CanTransitionTo(MatchStatusCompleted) {
+		t.Error("a postponed match cannot be rescheduled and then completed")
+	}
+}
+
+func TestMatchStatusIsDecidedAndIsValid(t *testing.T) {
+	tests := []struct {
+		status  MatchStatus
+		decided bool
+		valid   bool
+	}{
+		{status: MatchStatusScheduled, valid: true},
+		{status: MatchStatusInProgress, valid: true},
+		{status: MatchStatusPostponed, valid: true},
+		{status: MatchStatusAbandoned, valid: true},
+		{status: MatchStatusCancelled, valid: true},
+		{status: MatchStatusCompleted, decided: true, valid: true},
+		{status: MatchStatusForfeited, decided: true, valid: true},
+		{status: MatchStatus("finished")},
+		{status: MatchStatus("")},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			if got := tt.status.IsDecided(); got != tt.decided {
+				t.Errorf("IsDecided() = %v, want %v", got, tt.decided)
+			}
+			if got := tt.status.IsValid(); got != tt.valid {
+				t.Errorf("IsValid() = %v, want %v", got, tt.valid)
+			}
+		})
+	}
+}
+
+func TestOpenAndDecidedStatusesCoverEveryStatus(t *testing.T) {
+	seen := make(map[MatchStatus]int)
+	for _, s := range OpenMatchStatuses {
+		if s.IsDecided() {
+			t.Errorf("open status %s is decided", s)
+		}
+		seen[s]++
+	}
+	for _, s := range DecidedMatchStatuses {
+		if !s.IsDecided() {
+			t.Errorf("decided status %s is not decided", s)
+		}
+		seen[s]++
+	}
+	for _, s := range allMatchStatuses {
+		want := 1
+		if s == MatchStatusCancelled {
+			want = 0 // neither open nor decided
+		}
+		if seen[s] != want {
+			t.Errorf("status %s is listed %d times, want %d", s, seen[s], want)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// OfficialRole defines the duty of an official in a match
+type OfficialRole string
+
+const (
+	OfficialRoleReferee           OfficialRole = "referee"
+	OfficialRoleAssistantReferee  OfficialRole = "assistant_referee"
+	OfficialRoleMatchCommissioner OfficialRole = "match_commissioner"
+)
+
+// OfficialRoleLimits is how many officials of each role a match can have
+var OfficialRoleLimits = map[OfficialRole]int{
+	OfficialRoleReferee:           1,
+	OfficialRoleAssistantReferee:  2,
+	OfficialRoleMatchCommissioner: 1,
+}
+
+type Official struct {
+	ID               uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name             string         `json:"name" gorm:"not null"`
+	Phone            string         `json:"phone"`
+	City             string         `json:"city"`
+	LicenseLevel     string         `json:"license_level"`      // e.g. C1, C2, C3
+	AffiliatedTeamID *uint          `json:"affiliated_team_id"` // cannot officiate this team's matches
+	AffiliatedTeam   *Team          `json:"affiliated_team,omitempty" gorm:"foreignKey:AffiliatedTeamID"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// MatchOfficial assigns an official to a match in one role
+type MatchOfficial struct {
+	ID         uint         `json:"id" gorm:"primaryKey;autoIncrement"`
+	MatchID    uint         `json:"match_id" gorm:"not null;uniqueIndex:idx_match_official"`
+	Match      *Match       `json:"match,omitempty" gorm:"foreignKey:MatchID"`
+	OfficialID uint         `json:"official_id" gorm:"not null;uniqueIndex:idx_match_official;index"`
+	Official   *Official    `json:"official,omitempty" gorm:"foreignKey:OfficialID"`
+	Role       OfficialRole `json:"role" gorm:"not null"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
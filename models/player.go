@@ -18,7 +18,7 @@ const (
 
 type Player struct {
 	ID           uint           `json:"id" gorm:"primaryKey;autoIncrement"`
-	TeamID       uint           `json:"team_id" gorm:"not null"`
+	TeamID       uint           `json:"team_id" gorm:"not null"` // current team; past teams are in the memberships
 	Team         *Team          `json:"team,omitempty" gorm:"foreignKey:TeamID"`
 	Name         string         `json:"name" gorm:"not null"`
 	Height       float64        `json:"height" gorm:"not null"` // cm
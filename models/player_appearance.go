@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PlayerAppearance is the time a player spent on the pitch in a match, worked out from the
+// lineup and the substitutions and sendings-off of the timeline when the result is submitted
+type PlayerAppearance struct {
+	ID            uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	MatchResultID uint           `json:"match_result_id" gorm:"not null;index"`
+	MatchID       uint           `json:"match_id" gorm:"not null;index"`
+	PlayerID      uint           `json:"player_id" gorm:"not null;index"`
+	Player        *Player        `json:"player,omitempty" gorm:"foreignKey:PlayerID"`
+	TeamID        uint           `json:"team_id" gorm:"not null"`
+	Starter       bool           `json:"starter" gorm:"not null"`
+	MinuteOn      int            `json:"minute_on" gorm:"not null"`  // 0 for starters
+	MinuteOff     int            `json:"minute_off" gorm:"not null"` // substitution, sending off or full time
+	MinutesPlayed int            `json:"minutes_played" gorm:"not null"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `json:"-" gorm:"index"`
+}
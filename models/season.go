@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TieBreaker defines a rule used to order teams level on points
+type TieBreaker string
+
+const (
+	TieBreakerGoalDifference TieBreaker = "goal_difference"
+	TieBreakerGoalsFor       TieBreaker = "goals_for"
+	TieBreakerHeadToHead     TieBreaker = "head_to_head"
+)
+
+// DefaultTieBreakers is applied when a season does not configure its own order
+const DefaultTieBreakers = "goal_difference,goals_for,head_to_head"
+
+type Season struct {
+	ID            uint         `json:"id" gorm:"primaryKey;autoIncrement"`
+	CompetitionID uint         `json:"competition_id" gorm:"not null;index"`
+	Competition   *Competition `json:"competition,omitempty" gorm:"foreignKey:CompetitionID"`
+	Name          string       `json:"name" gorm:"not null"`       // e.g. 2025/2026
+	StartDate     string       `json:"start_date" gorm:"not null"` // YYYY-MM-DD
+	EndDate       string       `json:"end_date" gorm:"not null"`   // YYYY-MM-DD
+	Teams         []Team       `json:"teams,omitempty" gorm:"many2many:season_teams"`
+	PointsForWin  int          `json:"points_for_win" gorm:"not null;default:3"`
+	PointsForDraw int          `json:"points_for_draw" gorm:"not null;default:1"`
+	TieBreakers   string       `json:"tie_breakers" gorm:"not null;default:'goal_difference,goals_for,head_to_head'"` // comma-separated, in order
+
+	// Group stage qualification and knockout schedule (group_knockout competitions)
+	Groups               []SeasonGroup  `json:"groups,omitempty" gorm:"foreignKey:SeasonID"`
+	DrawSeed             *int64         `json:"draw_seed"` // seed of the last group draw, replays the same draw
+	QualifiersPerGroup   int            `json:"qualifiers_per_group" gorm:"not null;default:2"`
+	BestThirdPlaced      int            `json:"best_third_placed" gorm:"not null;default:0"` // extra qualifiers from the next position, across groups
+	KnockoutStartDate    string         `json:"knockout_start_date"`                         // YYYY-MM-DD, empty = one interval after the last group match
+	KnockoutIntervalDays int            `json:"knockout_interval_days" gorm:"not null;default:7"`
+	KnockoutMatchTime    string         `json:"knockout_match_time" gorm:"not null;default:'19:00'"` // HH:MM
+	CreatedAt            time.Time      `json:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at"`
+	DeletedAt            gorm.DeletedAt `json:"-" gorm:"index"`
+}
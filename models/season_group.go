@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SeasonGroup is a group of a group stage, e.g. "Grup A"
+type SeasonGroup struct {
+	ID        uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	SeasonID  uint           `json:"season_id" gorm:"not null;index"`
+	Name      string         `json:"name" gorm:"not null"`
+	Teams     []Team         `json:"teams,omitempty" gorm:"many2many:season_group_teams"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ShootoutKick is one penalty taken in a shootout, numbered in the order it was taken
+type ShootoutKick struct {
+	ID            uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	MatchResultID uint           `json:"match_result_id" gorm:"not null;index"`
+	KickNumber    int            `json:"kick_number" gorm:"not null"`
+	PlayerID      uint           `json:"player_id" gorm:"not null"`
+	Player        *Player        `json:"player,omitempty" gorm:"foreignKey:PlayerID"`
+	TeamID        uint           `json:"team_id" gorm:"not null"`
+	Scored        bool           `json:"scored" gorm:"not null"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `json:"-" gorm:"index"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DivisionMovement describes where a team went at the end of a season
+type DivisionMovement string
+
+const (
+	DivisionPromoted  DivisionMovement = "promoted"
+	DivisionRelegated DivisionMovement = "relegated"
+	DivisionStayed    DivisionMovement = "stayed"
+)
+
+// TeamDivisionHistory records the division a team played in for one finished season
+type TeamDivisionHistory struct {
+	ID            uint             `json:"id" gorm:"primaryKey;autoIncrement"`
+	TeamID        uint             `json:"team_id" gorm:"not null;uniqueIndex:idx_team_division_season"`
+	Team          *Team            `json:"team,omitempty" gorm:"foreignKey:TeamID"`
+	SeasonID      uint             `json:"season_id" gorm:"not null;uniqueIndex:idx_team_division_season"`
+	Season        *Season          `json:"season,omitempty" gorm:"foreignKey:SeasonID"`
+	CompetitionID uint             `json:"competition_id" gorm:"not null"`
+	Tier          int              `json:"tier" gorm:"not null"`
+	FinalPosition int              `json:"final_position" gorm:"not null"`
+	Movement      DivisionMovement `json:"movement" gorm:"not null"`
+	CreatedAt     time.Time        `json:"created_at"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt   `json:"-" gorm:"index"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TeamMembership is one spell of a player at a team. The spell covers FromDate up to,
+// but not including, ToDate; the current spell has no ToDate.
+type TeamMembership struct {
+	ID           uint    `json:"id" gorm:"primaryKey;autoIncrement"`
+	PlayerID     uint    `json:"player_id" gorm:"not null;index"`
+	Player       *Player `json:"player,omitempty" gorm:"foreignKey:PlayerID"`
+	TeamID       uint    `json:"team_id" gorm:"not null;index"`
+	Team         *Team   `json:"team,omitempty" gorm:"foreignKey:TeamID"`
+	FromDate     string  `json:"from_date" gorm:"not null"` // YYYY-MM-DD
+	ToDate       *string `json:"to_date"`                   // YYYY-MM-DD, nil while the player is still at the team
+	JerseyNumber int     `json:"jersey_number" gorm:"not null"`
+
+	// Set when an admin allowed the move outside a transfer window or during a roster freeze
+	OverrideReason string `json:"override_reason,omitempty"`
+	OverriddenByID *uint  `json:"overridden_by_id,omitempty"`
+	OverriddenBy   *User  `json:"overridden_by,omitempty" gorm:"foreignKey:OverriddenByID"`
+
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TransferWindow is a period in which teams of a competition can register and transfer players.
+// A competition without windows puts no limit on registrations.
+type TransferWindow struct {
+	ID            uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	CompetitionID uint           `json:"competition_id" gorm:"not null;index"`
+	Competition   *Competition   `json:"competition,omitempty" gorm:"foreignKey:CompetitionID"`
+	Name          string         `json:"name" gorm:"not null"`       // e.g. Mid-season window
+	StartDate     string         `json:"start_date" gorm:"not null"` // YYYY-MM-DD, first day open
+	EndDate       string         `json:"end_date" gorm:"not null"`   // YYYY-MM-DD, last day open
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// RosterFreeze locks the squad of a team: no player can join or leave it while the freeze lasts
+type RosterFreeze struct {
+	ID          uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	TeamID      uint           `json:"team_id" gorm:"not null;index"`
+	Team        *Team          `json:"team,omitempty" gorm:"foreignKey:TeamID"`
+	FromDate    string         `json:"from_date" gorm:"not null"` // YYYY-MM-DD
+	ToDate      *string        `json:"to_date"`                   // YYYY-MM-DD, last frozen day; nil until lifted
+	Reason      string         `json:"reason" gorm:"not null"`
+	CreatedByID uint           `json:"created_by_id" gorm:"not null"`
+	CreatedBy   *User          `json:"created_by,omitempty" gorm:"foreignKey:CreatedByID"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+}
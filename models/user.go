@@ -6,12 +6,18 @@ import (
 	"gorm.io/gorm"
 )
 
+// User roles. Admins manage transfer windows and roster freezes and can override them.
+const (
+	RoleAdmin = "admin"
+	RoleUser  = "user"
+)
+
 type User struct {
 	ID        uint           `json:"id" gorm:"primaryKey;autoIncrement"`
 	Username  string         `json:"username" gorm:"uniqueIndex;not null"`
 	Email     string         `json:"email" gorm:"uniqueIndex;not null"`
 	Password  string         `json:"-" gorm:"not null"`
-	Role      string         `json:"role" gorm:"default:'admin'"`
+	Role      string         `json:"role" gorm:"not null;default:'user'"` // admin or user
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
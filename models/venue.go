@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// VenueSurface defines the playing surface of a venue
+type VenueSurface string
+
+const (
+	SurfaceGrass      VenueSurface = "grass"
+	SurfaceArtificial VenueSurface = "artificial"
+	SurfaceHybrid     VenueSurface = "hybrid"
+)
+
+type Venue struct {
+	ID        uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name      string         `json:"name" gorm:"not null"`
+	Address   string         `json:"address" gorm:"not null"`
+	City      string         `json:"city" gorm:"not null"`
+	Capacity  int            `json:"capacity" gorm:"not null;default:0"`
+	Latitude  *float64       `json:"latitude"`
+	Longitude *float64       `json:"longitude"`
+	Surface   VenueSurface   `json:"surface" gorm:"not null;default:'grass'"`
+	Timezone  string         `json:"timezone"` // IANA time zone of kickoffs, empty to use the competition's
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
@@ -17,6 +17,11 @@ func SetupRoutes(r *gin.Engine) {
 		auth.POST("/login", handlers.Login)
 	}
 
+	// ─── Calendar feeds (public) ──────────────────────────────────────
+	// Calendar apps subscribe without a token
+	api.GET("/teams/:id/calendar.ics", handlers.GetTeamCalendar)
+	api.GET("/competitions/:id/calendar.ics", handlers.GetCompetitionCalendar)
+
 	// ─── Protected routes ─────────────────────────────────────────────
 	protected := api.Group("/")
 	protected.Use(middleware.AuthMiddleware())
@@ -33,6 +38,10 @@ func SetupRoutes(r *gin.Engine) {
 			teams.PUT("/:id", handlers.UpdateTeam)
 			teams.DELETE("/:id", handlers.DeleteTeam)
 			teams.GET("/:id/players", handlers.GetPlayersByTeam)
+			teams.GET("/:id/divisions", handlers.GetTeamDivisionHistory)
+			teams.GET("/:id/roster-freezes", handlers.GetRosterFreezes)
+			teams.POST("/:id/roster-freezes", middleware.AdminOnly(), handlers.CreateRosterFreeze)
+			teams.DELETE("/:id/roster-freezes/:freeze_id", middleware.AdminOnly(), handlers.LiftRosterFreeze)
 		}
 
 		// Players
@@ -43,6 +52,71 @@ func SetupRoutes(r *gin.Engine) {
 			players.GET("/:id", handlers.GetPlayerByID)
 			players.PUT("/:id", handlers.UpdatePlayer)
 			players.DELETE("/:id", handlers.DeletePlayer)
+			players.GET("/:id/memberships", handlers.GetPlayerMemberships)
+			players.POST("/:id/transfer", handlers.TransferPlayer)
+		}
+
+		// Venues
+		venues := protected.Group("/venues")
+		{
+			venues.GET("", handlers.GetAllVenues)
+			venues.POST("", handlers.CreateVenue)
+			venues.GET("/:id", handlers.GetVenueByID)
+			venues.PUT("/:id", handlers.UpdateVenue)
+			venues.DELETE("/:id", handlers.DeleteVenue)
+		}
+
+		// Officials
+		officials := protected.Group("/officials")
+		{
+			officials.GET("", handlers.GetAllOfficials)
+			officials.POST("", handlers.CreateOfficial)
+			officials.GET("/:id", handlers.GetOfficialByID)
+			officials.PUT("/:id", handlers.UpdateOfficial)
+			officials.DELETE("/:id", handlers.DeleteOfficial)
+			officials.GET("/:id/stats", handlers.GetOfficialStats)
+		}
+
+		// Competitions
+		competitions := protected.Group("/competitions")
+		{
+			competitions.GET("", handlers.GetAllCompetitions)
+			competitions.POST("", handlers.CreateCompetition)
+			competitions.GET("/:id", handlers.GetCompetitionByID)
+			competitions.PUT("/:id", handlers.UpdateCompetition)
+			competitions.DELETE("/:id", handlers.DeleteCompetition)
+			competitions.GET("/:id/seasons", handlers.GetSeasonsByCompetition)
+			competitions.POST("/:id/seasons", handlers.CreateSeason)
+			competitions.GET("/:id/transfer-windows", handlers.GetTransferWindows)
+			competitions.POST("/:id/transfer-windows", middleware.AdminOnly(), handlers.CreateTransferWindow)
+			competitions.DELETE("/:id/transfer-windows/:window_id", middleware.AdminOnly(), handlers.DeleteTransferWindow)
+		}
+
+		// Seasons
+		seasons := protected.Group("/seasons")
+		{
+			seasons.POST("/rollover", handlers.RolloverDivisions)
+			seasons.GET("/:id", handlers.GetSeasonByID)
+			seasons.PUT("/:id", handlers.UpdateSeason)
+			seasons.DELETE("/:id", handlers.DeleteSeason)
+			seasons.GET("/:id/standings", handlers.GetSeasonStandings)
+			seasons.GET("/:id/standings/history", handlers.GetSeasonStandingsHistory)
+			seasons.GET("/:id/assists", handlers.GetSeasonAssists)
+			seasons.POST("/:id/fixtures/generate", handlers.GenerateSeasonFixtures)
+			seasons.GET("/:id/brackets", handlers.GetSeasonBrackets)
+			seasons.POST("/:id/brackets", handlers.CreateSeasonBracket)
+			seasons.GET("/:id/groups", handlers.GetSeasonGroups)
+			seasons.POST("/:id/groups", handlers.CreateSeasonGroup)
+			seasons.DELETE("/:id/groups/:group_id", handlers.DeleteSeasonGroup)
+			seasons.PUT("/:id/group-stage", handlers.UpdateSeasonGroupStage)
+			seasons.POST("/:id/group-stage/seed", handlers.SeedSeasonKnockout)
+			seasons.POST("/:id/draw", handlers.DrawSeasonGroups)
+		}
+
+		// Brackets
+		brackets := protected.Group("/brackets")
+		{
+			brackets.GET("/:id", handlers.GetBracketByID)
 		}
 
 		// Matches
@@ -54,6 +128,25 @@ func SetupRoutes(r *gin.Engine) {
 			matches.PUT("/:id", handlers.UpdateMatch)
 			matches.DELETE("/:id", handlers.DeleteMatch)
 
+			// Match Status
+			matches.POST("/:id/status", handlers.TransitionMatchStatus)
+			matches.GET("/:id/status-history", handlers.GetMatchStatusHistory)
+
+			// Match Lineups
+			matches.GET("/:id/lineups", handlers.GetMatchLineups)
+			matches.POST("/:id/lineups", handlers.SubmitMatchLineup)
+			matches.POST("/:id/lineups/:team_id/lock", handlers.LockMatchLineup)
+
+			// Match Statistics
+			matches.GET("/:id/stats", handlers.GetMatchStats)
+			matches.POST("/:id/stats/teams", handlers.SubmitTeamMatchStats)
+			matches.POST("/:id/stats/players", handlers.SubmitPlayerMatchStats)
+
+			// Match Officials
+			matches.GET("/:id/officials", handlers.GetMatchOfficials)
+			matches.POST("/:id/officials", handlers.AssignMatchOfficial)
+			matches.DELETE("/:id/officials/:official_id", handlers.UnassignMatchOfficial)
+
 			// Match Result
 			matches.POST("/:id/result", handlers.SubmitMatchResult)
 			matches.GET("/:id/result", handlers.GetMatchResult)
@@ -65,5 +158,8 @@ func SetupRoutes(r *gin.Engine) {
 			reports.GET("/matches", handlers.GetAllReports)
 			reports.GET("/matches/:id", handlers.GetMatchReport)
 		}
+
+		// Suspensions
+		protected.GET("/suspensions", handlers.GetSuspensions)
 	}
 }
@@ -34,6 +34,14 @@ func ErrorResponse(c *gin.Context, statusCode int, message string) {
 	})
 }
 
+func ErrorResponseWithData(c *gin.Context, statusCode int, message string, data interface{}) {
+	c.JSON(statusCode, Response{
+		Success: false,
+		Message: message,
+		Data:    data,
+	})
+}
+
 func ValidationErrorResponse(c *gin.Context, message string) {
 	c.JSON(http.StatusBadRequest, Response{
 		Success: false,